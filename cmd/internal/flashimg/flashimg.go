@@ -0,0 +1,180 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flashimg reads the RP2350 program images from the ELF, UF2 and raw
+// binary files and writes them in the UF2 and raw binary formats.
+package flashimg
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FlashBase is the XIP address of the beginning of the Flash memory. It's
+// assumed to be the load address of the raw binary images.
+const FlashBase = 0x10000000
+
+// UF2 family IDs
+const (
+	FamilyAbsolute = 0xe48bff57 // written at the absolute address
+	FamilyData     = 0xe48bff58 // data partition
+	FamilyArmS     = 0xe48bff59 // Arm Secure executable
+	FamilyRISCV    = 0xe48bff5a // RISC-V executable
+	FamilyArmNS    = 0xe48bff5b // Arm Non-secure executable
+)
+
+const (
+	uf2Magic0    = 0x0a324655
+	uf2Magic1    = 0x9e5d5157
+	uf2MagicEnd  = 0x0ab16f30
+	uf2FamilyID  = 0x00002000 // flag: the family ID is present
+	uf2BlockSize = 512
+	uf2Payload   = 256
+)
+
+// maxSize limits the size of the image to catch the files that contain
+// segments far apart from each other (eg. Flash and RAM).
+const maxSize = 32 << 20
+
+// Image is a contiguous memory image.
+type Image struct {
+	Addr   uint32 // load address of Data[0]
+	Data   []byte
+	Family uint32 // UF2 family ID or 0 if unknown
+}
+
+// Load reads the image from the named file. The file format is detected by
+// its content.
+func Load(name string) (*Image, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var img *Image
+	switch {
+	case bytes.HasPrefix(b, []byte(elf.ELFMAG)):
+		img, err = loadELF(b)
+	case len(b) >= uf2BlockSize && binary.LittleEndian.Uint32(b) == uf2Magic0:
+		img, err = loadUF2(b)
+	default:
+		img = &Image{Addr: FlashBase, Data: b}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return img, nil
+}
+
+// region is a range of memory to be filled with data.
+type region struct {
+	addr uint32
+	data []byte
+}
+
+// assemble creates the image from the list of regions. The gaps are filled
+// with 0xff (the erased Flash content).
+func assemble(rs []region) (*Image, error) {
+	if len(rs) == 0 {
+		return nil, errors.New("no data")
+	}
+	start, end := rs[0].addr, rs[0].addr
+	for _, r := range rs {
+		start = min(start, r.addr)
+		end = max(end, r.addr+uint32(len(r.data)))
+	}
+	if end-start > maxSize {
+		return nil, errors.New("the data are spread over too large memory area")
+	}
+	data := bytes.Repeat([]byte{0xff}, int(end-start))
+	for _, r := range rs {
+		copy(data[r.addr-start:], r.data)
+	}
+	return &Image{Addr: start, Data: data}, nil
+}
+
+func loadELF(b []byte) (*Image, error) {
+	f, err := elf.NewFile(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	var rs []region
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD || p.Filesz == 0 {
+			continue
+		}
+		data := make([]byte, p.Filesz)
+		if _, err := p.ReadAt(data, 0); err != nil {
+			return nil, err
+		}
+		rs = append(rs, region{uint32(p.Paddr), data})
+	}
+	return assemble(rs)
+}
+
+func loadUF2(b []byte) (*Image, error) {
+	var (
+		rs     []region
+		family uint32
+	)
+	for ; len(b) >= uf2BlockSize; b = b[uf2BlockSize:] {
+		w := func(i int) uint32 { return binary.LittleEndian.Uint32(b[i*4:]) }
+		if w(0) != uf2Magic0 || w(1) != uf2Magic1 || w(127) != uf2MagicEnd {
+			return nil, errors.New("bad UF2 block")
+		}
+		size := w(4)
+		if w(2)&1 != 0 || size > 476 {
+			continue // not main flash or bad payload size
+		}
+		if w(2)&uf2FamilyID != 0 {
+			family = w(7)
+		}
+		rs = append(rs, region{w(3), b[32 : 32+size]})
+	}
+	img, err := assemble(rs)
+	if err != nil {
+		return nil, err
+	}
+	img.Family = family
+	return img, nil
+}
+
+// WriteFile writes the image to the named file. The UF2 format is used if the
+// file name has the .uf2 extension, otherwise the raw binary data are written.
+// The family is the UF2 family ID. If it's zero the img.Family is used.
+func (img *Image) WriteFile(name string, family uint32) error {
+	if filepath.Ext(name) != ".uf2" {
+		return os.WriteFile(name, img.Data, 0644)
+	}
+	if family == 0 {
+		family = img.Family
+	}
+	if family == 0 {
+		return errors.New("unknown UF2 family ID")
+	}
+	// Align the payload to the 256-byte pages.
+	addr := img.Addr &^ (uf2Payload - 1)
+	data := append(bytes.Repeat([]byte{0xff}, int(img.Addr-addr)), img.Data...)
+	n := (len(data) + uf2Payload - 1) / uf2Payload
+	out := make([]byte, 0, n*uf2BlockSize)
+	for i := 0; i < n; i++ {
+		page := data[i*uf2Payload : min(len(data), (i+1)*uf2Payload)]
+		var blk [uf2BlockSize]byte
+		for k, v := range [...]uint32{
+			uf2Magic0, uf2Magic1, uf2FamilyID, addr + uint32(i*uf2Payload),
+			uf2Payload, uint32(i), uint32(n), family,
+		} {
+			binary.LittleEndian.PutUint32(blk[k*4:], v)
+		}
+		copy(blk[32:32+uf2Payload], bytes.Repeat([]byte{0xff}, uf2Payload))
+		copy(blk[32:], page)
+		binary.LittleEndian.PutUint32(blk[uf2BlockSize-4:], uf2MagicEnd)
+		out = append(out, blk[:]...)
+	}
+	return os.WriteFile(name, out, 0644)
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/embeddedgo/pico/cmd/internal/flashimg"
+	"github.com/embeddedgo/pico/picobin"
+)
+
+func imageCmd(fs *flag.FlagSet) func(fs *flag.FlagSet) {
+	output := fs.String("o", "", "output file (default IMAGE with the .img.uf2 extension)")
+	typ := fs.String("type", "exe", "image type: exe, data")
+	cpu := fs.String("cpu", "arm", "CPU architecture: arm, riscv")
+	sec := fs.String("security", "s", "security mode of the Arm executable: s, ns")
+	tbyb := fs.Bool("tbyb", false, "mark the image as try before you buy")
+	version := fs.String("version", "", "image version: MAJOR.MINOR")
+	rollback := fs.Uint("rollback", 0, "rollback version (requires -rows)")
+	rows := fs.String("rows", "", "comma separated OTP rows for the rollback version")
+	loadmap := fs.Bool("loadmap", false, "add the load map that covers the whole image")
+	family := fs.Uint("family", 0, "UF2 family ID (default: derived from the image type)")
+
+	return func(fs *flag.FlagSet) {
+		if fs.NArg() != 1 {
+			fs.Usage()
+			usage()
+		}
+		name := fs.Arg(0)
+		img := load(name)
+
+		// Start from the existing IMAGE_DEF.
+		d := &picobin.ImageDef{Type: picobin.DefaultImageType}
+		if _, blocks, err := picobin.Loop(img.Data); err == nil {
+			for _, b := range blocks {
+				if def := picobin.ParseImageDef(b); def != nil {
+					d = def
+				}
+			}
+		}
+		d.LoadMap = nil
+		set := make(map[string]bool)
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		if set["type"] {
+			d.Type &^= picobin.TypeMask
+			switch *typ {
+			case "exe":
+				d.Type |= picobin.TypeExe
+			case "data":
+				d.Type |= picobin.TypeData
+			default:
+				die("bad image type:", *typ)
+			}
+		}
+		if set["cpu"] {
+			d.Type &^= picobin.CPUMask
+			switch *cpu {
+			case "arm":
+				d.Type |= picobin.CPUArm
+			case "riscv":
+				d.Type |= picobin.CPURISCV
+			default:
+				die("bad CPU architecture:", *cpu)
+			}
+		}
+		if set["security"] {
+			d.Type &^= picobin.SecurityMask
+			switch *sec {
+			case "s":
+				d.Type |= picobin.SecurityS
+			case "ns":
+				d.Type |= picobin.SecurityNS
+			default:
+				die("bad security mode:", *sec)
+			}
+		}
+		if set["tbyb"] {
+			d.Type &^= picobin.TBYB
+			if *tbyb {
+				d.Type |= picobin.TBYB
+			}
+		}
+		if set["version"] {
+			major, minor, ok := strings.Cut(*version, ".")
+			ma, err := strconv.ParseUint(major, 10, 16)
+			mi, err1 := strconv.ParseUint(minor, 10, 16)
+			if !ok || err != nil || err1 != nil {
+				die("bad version:", *version)
+			}
+			d.Major, d.Minor = uint16(ma), uint16(mi)
+		}
+		if set["rows"] {
+			d.RollbackRows = nil
+			for _, s := range strings.Split(*rows, ",") {
+				r, err := strconv.ParseUint(s, 0, 16)
+				if err != nil {
+					die("bad OTP row:", s)
+				}
+				d.RollbackRows = append(d.RollbackRows, uint16(r))
+			}
+		}
+		if set["rollback"] {
+			if len(d.RollbackRows) == 0 {
+				die("-rollback requires -rows")
+			}
+			if *rollback > uint(len(d.RollbackRows))*24 {
+				die("rollback version doesn't fit in the OTP rows")
+			}
+			d.Rollback = uint16(*rollback)
+		}
+
+		data, err := picobin.SetImageDef(img.Data, d)
+		if err != nil {
+			die(name+":", err)
+		}
+		if *loadmap {
+			// The load map entries are relative to the block so we need to
+			// know where it's placed. SetImageDef replaces the block appended
+			// by the previous call at the same offset.
+			off := len(data) - (&picobin.Block{Items: d.Items()}).Size()
+			d.LoadMap = []picobin.LoadMapEntry{{
+				Storage: int32(-off), Runtime: img.Addr, Size: uint32(off),
+			}}
+			if data, err = picobin.SetImageDef(data, d); err != nil {
+				die(name+":", err)
+			}
+		}
+		img.Data = data
+
+		fam := uint32(*family)
+		if fam == 0 {
+			fam = familyOf(d.Type)
+		}
+		if *output == "" {
+			*output = outName(name, ".img.uf2")
+		}
+		if err := img.WriteFile(*output, fam); err != nil {
+			die(err)
+		}
+	}
+}
+
+// familyOf returns the UF2 family ID of the image of type t.
+func familyOf(t picobin.ImageType) uint32 {
+	switch {
+	case t&picobin.TypeMask == picobin.TypeData:
+		return flashimg.FamilyData
+	case t&picobin.CPUMask == picobin.CPURISCV:
+		return flashimg.FamilyRISCV
+	case t&picobin.SecurityMask == picobin.SecurityNS:
+		return flashimg.FamilyArmNS
+	}
+	return flashimg.FamilyArmS
+}
+
+func typeString(t picobin.ImageType) string {
+	var s []string
+	switch t & picobin.TypeMask {
+	case picobin.TypeExe:
+		s = append(s, "exe")
+	case picobin.TypeData:
+		s = append(s, "data")
+	default:
+		s = append(s, fmt.Sprintf("type(%d)", t&picobin.TypeMask))
+	}
+	if t&picobin.TypeMask == picobin.TypeExe {
+		switch t & picobin.CPUMask {
+		case picobin.CPUArm:
+			s = append(s, "arm")
+		case picobin.CPURISCV:
+			s = append(s, "riscv")
+		}
+		switch t & picobin.SecurityMask {
+		case picobin.SecurityS:
+			s = append(s, "secure")
+		case picobin.SecurityNS:
+			s = append(s, "non-secure")
+		}
+		switch t & picobin.ChipMask {
+		case picobin.ChipRP2040:
+			s = append(s, "rp2040")
+		case picobin.ChipRP2350:
+			s = append(s, "rp2350")
+		}
+	}
+	if t&picobin.TBYB != 0 {
+		s = append(s, "tbyb")
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/embeddedgo/pico/picobin"
+)
+
+var itemNames = map[uint8]string{
+	picobin.ItemVectorTable:    "VECTOR_TABLE",
+	picobin.ItemRollingWindow:  "ROLLING_WINDOW_DELTA",
+	picobin.ItemLoadMap:        "LOAD_MAP",
+	picobin.ItemSignature:      "SIGNATURE",
+	picobin.ItemPartitionTable: "PARTITION_TABLE",
+	picobin.ItemImageType:      "IMAGE_TYPE",
+	picobin.ItemEntryPoint:     "ENTRY_POINT",
+	picobin.ItemHashDef:        "HASH_DEF",
+	picobin.ItemVersion:        "VERSION",
+	picobin.ItemHashValue:      "HASH_VALUE",
+	picobin.ItemIgnored:        "IGNORED",
+}
+
+func infoCmd(fs *flag.FlagSet) func(fs *flag.FlagSet) {
+	return func(fs *flag.FlagSet) {
+		if fs.NArg() != 1 {
+			usage()
+		}
+		name := fs.Arg(0)
+		img := load(name)
+		fmt.Printf("%s: %d bytes at 0x%08x\n", name, len(img.Data), img.Addr)
+		offs, blocks, err := picobin.Loop(img.Data)
+		if err != nil {
+			die(name+":", err)
+		}
+		for i, b := range blocks {
+			fmt.Printf("\nblock at 0x%08x (offset 0x%x)\n", img.Addr+uint32(offs[i]), offs[i])
+			for _, it := range b.Items {
+				s, ok := itemNames[it.Type()]
+				if !ok {
+					s = fmt.Sprintf("item(0x%02x)", it.Type())
+				}
+				fmt.Printf("  %-20s %d words\n", s, len(it))
+			}
+			if d := picobin.ParseImageDef(b); d != nil {
+				printImageDef(d, img.Addr+uint32(offs[i]))
+			}
+			if pt := picobin.ParsePartitionTable(b); pt != nil {
+				printPartitionTable(pt)
+			}
+		}
+	}
+}
+
+func printImageDef(d *picobin.ImageDef, addr uint32) {
+	fmt.Printf("  image type: %s\n", typeString(d.Type))
+	if d.Major != 0 || d.Minor != 0 || len(d.RollbackRows) != 0 {
+		fmt.Printf("  version:    %d.%d\n", d.Major, d.Minor)
+	}
+	if len(d.RollbackRows) != 0 {
+		fmt.Printf("  rollback:   %d, OTP rows: %#x\n", d.Rollback, d.RollbackRows)
+	}
+	if d.VectorTable != 0 {
+		fmt.Printf("  vectors:    0x%08x\n", d.VectorTable)
+	}
+	if d.EntryPoint != 0 {
+		fmt.Printf("  entry:      0x%08x, SP: 0x%08x\n", d.EntryPoint, d.StackPointer)
+	}
+	for _, e := range d.LoadMap {
+		fmt.Printf(
+			"  load:       0x%08x -> 0x%08x, %d bytes\n",
+			addr+uint32(e.Storage), e.Runtime, e.Size,
+		)
+	}
+}
+
+func printPartitionTable(pt *picobin.PartitionTable) {
+	for i, p := range pt.Partitions {
+		fmt.Printf(
+			"  partition %d: 0x%06x-0x%06x perm=%02x flags=%05x",
+			i, p.Offset(), p.Offset()+p.Size(), uint32(p.Perm)>>26, uint32(p.Flags),
+		)
+		if p.Name != "" {
+			fmt.Printf(" name=%q", p.Name)
+		}
+		if p.ID != 0 {
+			fmt.Printf(" id=%#x", p.ID)
+		}
+		if len(p.ExtraFamilies) != 0 {
+			fmt.Printf(" families=%#x", p.ExtraFamilies)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Picoimg creates the RP2350 boot ROM metadata blocks.
+//
+// Usage:
+//
+//	picoimg image [FLAGS] IMAGE
+//	picoimg pt [-o OUTPUT] SPEC
+//	picoimg info FILE
+//
+// The image command sets the IMAGE_DEF of the IMAGE (ELF, UF2 or raw binary
+// file). The IMAGE must contain a metadata block in its first 4 KiB. The
+// existing IMAGE_DEF is used as the starting point, modified according to the
+// flags and saved in the block appended to the end of IMAGE. The result is
+// written in the UF2 format (or raw binary if the output file extension isn't
+// .uf2). Use picosign to sign the resulting image.
+//
+// The pt command creates the UF2 file that writes the partition table
+// described by the JSON encoded SPEC file at the beginning of Flash. For
+// example, the following SPEC describes the A/B pair of 1 MiB partitions for
+// the firmware images and the data partition that occupies the rest of 4 MiB
+// Flash:
+//
+//	{
+//		"partitions": [
+//			{"name": "A", "start": "8K", "size": "1M", "families": ["rp2350-arm-s"]},
+//			{"name": "B", "start": "1032K", "size": "1M", "families": ["rp2350-arm-s"], "link": 0},
+//			{"name": "data", "start": "2056K", "size": "2040K", "families": ["data"]}
+//		]
+//	}
+//
+// The UF2 images for the rp2350-arm-s family are written by the boot ROM to
+// the A or B partition, the one that doesn't contain the currently booted
+// image.
+//
+// The info command prints the metadata blocks found in the FILE.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/embeddedgo/pico/cmd/internal/flashimg"
+)
+
+func die(v ...any) {
+	fmt.Fprintln(os.Stderr, append([]any{"picoimg:"}, v...)...)
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  picoimg image [FLAGS] IMAGE")
+	fmt.Fprintln(os.Stderr, "  picoimg pt [-o OUTPUT] SPEC")
+	fmt.Fprintln(os.Stderr, "  picoimg info FILE")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	fs := flag.NewFlagSet("picoimg "+os.Args[1], flag.ExitOnError)
+	var run func(fs *flag.FlagSet)
+	switch os.Args[1] {
+	case "image":
+		run = imageCmd(fs)
+	case "pt":
+		run = ptCmd(fs)
+	case "info":
+		run = infoCmd(fs)
+	default:
+		usage()
+	}
+	fs.Parse(os.Args[2:])
+	run(fs)
+}
+
+// outName returns the output file name derived from the input file name.
+func outName(name, suffix string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name)) + suffix
+}
+
+func load(name string) *flashimg.Image {
+	img, err := flashimg.Load(name)
+	if err != nil {
+		die(err)
+	}
+	return img
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/embeddedgo/pico/cmd/internal/flashimg"
+	"github.com/embeddedgo/pico/picobin"
+)
+
+// Size is a size or an offset in the SPEC file. It's a number or a string with
+// the optional K or M suffix.
+type Size int
+
+func (s *Size) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if u, err := strconv.Unquote(str); err == nil {
+		str = u
+	}
+	mul := 1
+	switch {
+	case strings.HasSuffix(str, "K"):
+		mul = 1 << 10
+	case strings.HasSuffix(str, "M"):
+		mul = 1 << 20
+	}
+	if mul != 1 {
+		str = str[:len(str)-1]
+	}
+	n, err := strconv.ParseUint(str, 0, 32)
+	if err != nil {
+		return fmt.Errorf("bad size: %s", b)
+	}
+	*s = Size(int(n) * mul)
+	return nil
+}
+
+type partSpec struct {
+	Name        string
+	ID          uint64
+	Start, Size Size
+	Perm        string // default: "s=rw,ns=rw,bl=rw"
+	Families    []string
+	Link        *int // index of the A partition (this is B)
+	Owner       *int // index of the owner partition
+	NotBootable []string
+	NoReboot    bool
+}
+
+type ptSpec struct {
+	Singleton     bool
+	Unpartitioned struct {
+		Perm     string
+		Families []string
+	}
+	Partitions []partSpec
+}
+
+func parsePerm(s string) (picobin.Perm, error) {
+	if s == "" {
+		return picobin.PermAll, nil
+	}
+	var p picobin.Perm
+	for _, f := range strings.Split(s, ",") {
+		who, rw, ok := strings.Cut(f, "=")
+		if !ok {
+			return 0, fmt.Errorf("bad permissions: %s", s)
+		}
+		var r, w picobin.Perm
+		switch who {
+		case "s":
+			r, w = picobin.PermSR, picobin.PermSW
+		case "ns":
+			r, w = picobin.PermNSR, picobin.PermNSW
+		case "bl":
+			r, w = picobin.PermBLR, picobin.PermBLW
+		default:
+			return 0, fmt.Errorf("bad permissions: %s", s)
+		}
+		for _, c := range rw {
+			switch c {
+			case 'r':
+				p |= r
+			case 'w':
+				p |= w
+			default:
+				return 0, fmt.Errorf("bad permissions: %s", s)
+			}
+		}
+	}
+	return p, nil
+}
+
+var families = map[string]picobin.PartFlags{
+	"rp2040":        picobin.AcceptsRP2040,
+	"absolute":      picobin.AcceptsAbsolute,
+	"data":          picobin.AcceptsData,
+	"rp2350-arm-s":  picobin.AcceptsArmS,
+	"rp2350-riscv":  picobin.AcceptsRISCV,
+	"rp2350-arm-ns": picobin.AcceptsArmNS,
+}
+
+// parseFamilies converts the list of family names and IDs to the flags and
+// the list of extra families.
+func parseFamilies(list []string) (flags picobin.PartFlags, extra []uint32, err error) {
+	for _, s := range list {
+		if f, ok := families[s]; ok {
+			flags |= f
+			continue
+		}
+		id, err := strconv.ParseUint(s, 0, 32)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unknown family: %s", s)
+		}
+		extra = append(extra, uint32(id))
+	}
+	return
+}
+
+func (ps *partSpec) partition() (p picobin.Partition, err error) {
+	const ss = picobin.SectorSize
+	if ps.Start%ss != 0 || ps.Size%ss != 0 || ps.Size == 0 {
+		return p, errors.New("start and size must be multiples of 4K")
+	}
+	p.First = int(ps.Start / ss)
+	p.Last = int((ps.Start+ps.Size)/ss) - 1
+	p.ID = ps.ID
+	p.Name = ps.Name
+	if p.Perm, err = parsePerm(ps.Perm); err != nil {
+		return
+	}
+	if p.Flags, p.ExtraFamilies, err = parseFamilies(ps.Families); err != nil {
+		return
+	}
+	switch {
+	case ps.Link != nil && ps.Owner != nil:
+		return p, errors.New("link and owner are mutually exclusive")
+	case ps.Link != nil:
+		p.Flags |= picobin.LinkA(*ps.Link)
+	case ps.Owner != nil:
+		p.Flags |= picobin.LinkOwner(*ps.Owner)
+	}
+	for _, arch := range ps.NotBootable {
+		switch arch {
+		case "arm":
+			p.Flags |= picobin.NotBootableArm
+		case "riscv":
+			p.Flags |= picobin.NotBootableRISCV
+		default:
+			return p, fmt.Errorf("unknown architecture: %s", arch)
+		}
+	}
+	if ps.NoReboot {
+		p.Flags |= picobin.NoReboot
+	}
+	return
+}
+
+func ptCmd(fs *flag.FlagSet) func(fs *flag.FlagSet) {
+	output := fs.String("o", "", "output file (default SPEC with the .uf2 extension)")
+
+	return func(fs *flag.FlagSet) {
+		if fs.NArg() != 1 {
+			fs.Usage()
+			usage()
+		}
+		name := fs.Arg(0)
+		b, err := os.ReadFile(name)
+		if err != nil {
+			die(err)
+		}
+		var spec ptSpec
+		if err = json.Unmarshal(b, &spec); err != nil {
+			die(name+":", err)
+		}
+		pt := &picobin.PartitionTable{Singleton: spec.Singleton}
+		if pt.Perm, err = parsePerm(spec.Unpartitioned.Perm); err != nil {
+			die(name+":", err)
+		}
+		fams := spec.Unpartitioned.Families
+		if fams == nil {
+			fams = []string{"absolute"}
+		}
+		if pt.Flags, _, err = parseFamilies(fams); err != nil {
+			die(name+":", err)
+		}
+		for i := range spec.Partitions {
+			p, err := spec.Partitions[i].partition()
+			if err != nil {
+				die(fmt.Sprintf("%s: partition %d: %v", name, i, err))
+			}
+			if p.First == 0 {
+				die(fmt.Sprintf("%s: partition %d overlaps the partition table", name, i))
+			}
+			pt.Partitions = append(pt.Partitions, p)
+		}
+		it, err := pt.Item()
+		if err != nil {
+			die(name+":", err)
+		}
+		blk := &picobin.Block{Items: []picobin.Item{it}}
+		img := &flashimg.Image{Addr: flashimg.FlashBase, Data: blk.Bytes()}
+		if *output == "" {
+			*output = outName(name, ".uf2")
+		}
+		if err := img.WriteFile(*output, flashimg.FamilyAbsolute); err != nil {
+			die(err)
+		}
+	}
+}
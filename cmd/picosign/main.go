@@ -0,0 +1,119 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Picosign signs the RP2350 program images for the secure boot.
+//
+// Usage:
+//
+//	picosign [-o OUTPUT] [-family ID] -key KEY IMAGE
+//	picosign -otp [-slot N] -key KEY
+//
+// The first form signs the IMAGE (ELF, UF2 or raw binary file) using the
+// secp256k1 private key read from the PEM encoded KEY file (see the
+// `openssl ecparam -genkey -name secp256k1` command). The image must contain
+// the IMAGE_DEF block in its first 4 KiB. The signed image is written to the
+// OUTPUT file in the UF2 format (or raw binary if the OUTPUT extension isn't
+// .uf2). The default OUTPUT is the IMAGE name with the extension replaced by
+// .signed.uf2.
+//
+// The second form prints the content of the OTP rows of the BOOTKEYn slot that
+// correspond to the public key read from KEY (private or public key file).
+// The device accepts the images signed with KEY if the secure boot is enabled
+// and the slot is marked valid (see the hal/otp package).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/embeddedgo/pico/cmd/internal/flashimg"
+	"github.com/embeddedgo/pico/picobin"
+	"github.com/embeddedgo/pico/picobin/secp256k1"
+)
+
+const (
+	rowBootKey0    = 0x080 // first row of BOOTKEY0
+	rowsPerBootKey = 16
+	numBootKeys    = 4
+)
+
+func die(v ...any) {
+	fmt.Fprintln(os.Stderr, append([]any{"picosign:"}, v...)...)
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  picosign [-o OUTPUT] [-family ID] -key KEY IMAGE")
+	fmt.Fprintln(os.Stderr, "  picosign -otp [-slot N] -key KEY")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	keyFile := flag.String("key", "", "PEM encoded secp256k1 key")
+	output := flag.String("o", "", "output file")
+	family := flag.Uint("family", 0, "UF2 family ID (default: from the input file or Arm Secure)")
+	otp := flag.Bool("otp", false, "print the BOOTKEY OTP rows")
+	slot := flag.Int("slot", 0, "BOOTKEY slot number (0-3)")
+	flag.Usage = usage
+	flag.Parse()
+	if *keyFile == "" {
+		usage()
+	}
+	key, err := os.ReadFile(*keyFile)
+	if err != nil {
+		die(err)
+	}
+	if *otp {
+		if flag.NArg() != 0 || uint(*slot) >= numBootKeys {
+			usage()
+		}
+		printOTP(key, *slot)
+		return
+	}
+	if flag.NArg() != 1 {
+		usage()
+	}
+	priv, err := secp256k1.ParsePrivateKey(key)
+	if err != nil {
+		die(*keyFile+":", err)
+	}
+	name := flag.Arg(0)
+	img, err := flashimg.Load(name)
+	if err != nil {
+		die(err)
+	}
+	pub := priv.PublicKey.Bytes()
+	img.Data, err = picobin.Sign(img.Data, img.Addr, &pub, priv.Sign)
+	if err != nil {
+		die(name+":", err)
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(name, filepath.Ext(name)) + ".signed.uf2"
+	}
+	fam := uint32(*family)
+	if fam == 0 && img.Family == 0 {
+		fam = flashimg.FamilyArmS
+	}
+	if err = img.WriteFile(*output, fam); err != nil {
+		die(err)
+	}
+}
+
+func printOTP(key []byte, slot int) {
+	pk, err := secp256k1.ParsePublicKey(key)
+	if err != nil {
+		die(err)
+	}
+	pub := pk.Bytes()
+	fmt.Printf("BOOTKEY%d: %x\n", slot, picobin.KeyHash(&pub))
+	row := rowBootKey0 + slot*rowsPerBootKey
+	for i, v := range picobin.BootKeyRows(&pub) {
+		fmt.Printf("0x%03x: 0x%04x\n", row+i, v)
+	}
+}
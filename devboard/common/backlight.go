@@ -0,0 +1,81 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package common
+
+import (
+	"time"
+
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/pwm"
+)
+
+// Backlight represents a display backlight which brightness is controlled
+// by a PWM signal.
+type Backlight struct {
+	c pwm.Channel
+	b uint8
+}
+
+// ConnectBacklight configures pin as a PWM output with the frequency hz and
+// returns the backlight controlled by this pin. The initial brightness is 0.
+// The PWM slice connected to the pin is used exclusively by the backlight.
+func ConnectBacklight(pin iomux.Pin, hz int) *Backlight {
+	p := pwm.PWM(0)
+	p.SetReset(false)
+	c := p.UsePin(pin)
+	s := c.Slice()
+	s.Setup(0)
+	s.SetFreq(hz)
+	s.Enable()
+	return &Backlight{c: c}
+}
+
+// Channel returns the PWM channel used by the backlight.
+func (b *Backlight) Channel() pwm.Channel { return b.c }
+
+// Brightness returns the current brightness.
+func (b *Backlight) Brightness() uint8 { return b.b }
+
+// SetBrightness sets the brightness to the value from 0 (off) to 255 (full
+// brightness). The brightness is proportional to the PWM duty cycle.
+func (b *Backlight) SetBrightness(v uint8) {
+	b.b = v
+	b.c.SetDuty(int(v), 255)
+}
+
+// SetOn sets the full brightness.
+func (b *Backlight) SetOn() { b.SetBrightness(255) }
+
+// SetOff turns the backlight off.
+func (b *Backlight) SetOff() { b.SetBrightness(0) }
+
+// Fade changes the brightness smoothly from the current value to v during the
+// time d. The brightness is changed in equal steps of the perceived brightness
+// (gamma 2 correction) so the fading looks linear for the human eye.
+func (b *Backlight) Fade(v uint8, d time.Duration) {
+	const steps = 64
+	p0 := isqrt(int(b.b) * 255) // perceived brightness
+	p1 := isqrt(int(v) * 255)
+	dt := d / steps
+	for i := 1; i < steps; i++ {
+		p := p0 + (p1-p0)*i/steps
+		b.SetBrightness(uint8((p*p + 127) / 255))
+		time.Sleep(dt)
+	}
+	b.SetBrightness(v)
+}
+
+func isqrt(x int) int {
+	r := 0
+	for bit := 1 << 16; bit != 0; bit >>= 2 {
+		if x >= r+bit {
+			x -= r + bit
+			r = r>>1 + bit
+		} else {
+			r >>= 1
+		}
+	}
+	return r
+}
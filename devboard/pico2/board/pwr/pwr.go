@@ -4,7 +4,12 @@
 
 package pwr
 
-import "github.com/embeddedgo/pico/hal/iomux"
+import (
+	"errors"
+
+	"github.com/embeddedgo/pico/hal/adc"
+	"github.com/embeddedgo/pico/hal/iomux"
+)
 
 const (
 	VBUS = iomux.P24 // VBUS presence
@@ -26,3 +31,19 @@ func SetPowerSave(ps bool) {
 		pin.SetAltFunc(iomux.OutHigh | iomux.OEEnable)
 	}
 }
+
+// ErrNoADC is returned by ReadVSYS if the VSYS pin isn't an analog input of
+// the used chip (for example RP2350B).
+var ErrNoADC = errors.New("pwr: VSYS pin isn't an ADC input")
+
+// ReadVSYS uses the ADC driver d to measure the VSYS voltage. It returns the
+// measured voltage in millivolts. ReadVSYS configures the VSYS pin as analog
+// input so it can be called without any prior setup.
+func ReadVSYS(d *adc.Driver) (mV int, err error) {
+	ch := d.UsePin(VSYS)
+	if ch < 0 {
+		return 0, ErrNoADC
+	}
+	mV, err = d.ReadMillivolts(ch)
+	return mV * 3, err
+}
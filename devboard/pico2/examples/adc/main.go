@@ -0,0 +1,63 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Adc reads the analog inputs GP26 and GP27 using single conversions and the
+// free-running round-robin mode. It also prints the die temperature and the
+// VSYS voltage.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/devboard/pico2/board/pwr"
+	"github.com/embeddedgo/pico/hal/adc/adc0dma"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+		ain0  = pins.GP26_A0
+		ain1  = pins.GP27_A1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	d := adc0dma.Driver()
+	ch0 := d.UsePin(ain0)
+	ch1 := d.UsePin(ain1)
+	fmt.Println("sample rate:", d.SetSampleRate(10e3), "S/s")
+	d.SetChannels(ch0, 1<<ch0|1<<ch1)
+
+	var buf [64]uint16
+	for {
+		v, err := d.Read(ch0)
+		fmt.Println("Read:", v, err)
+
+		n, err := d.Capture(buf[:])
+		var sum [2]int
+		for i, v := range buf[:n] {
+			sum[i&1] += int(v)
+		}
+		if n != 0 {
+			fmt.Println("Capture:", n, sum[0]*2/n, sum[1]*2/n, err)
+		} else {
+			fmt.Println("Capture:", n, err)
+		}
+
+		t, err := d.ReadTemp()
+		fmt.Printf("Temp: %.1f °C %v\n", t, err)
+		vsys, err := pwr.ReadVSYS(d)
+		fmt.Println("VSYS:", vsys, "mV", err)
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Aonrtc uses the always-on timer as a real-time clock. The time is set only
+// if the timer isn't running, so it survives the resets (try the RUN button).
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/aon"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	if aon.Running() {
+		if err := aon.SyncSystemTime(); err != nil {
+			fmt.Println(err)
+		}
+		fmt.Println("\nTime restored from the AON timer.")
+	} else {
+		aon.UseLPOSC(32768)
+		aon.Start()
+		aon.UseXOSC()
+		if err := aon.SetTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+			fmt.Println(err)
+		}
+		fmt.Println("\nAON timer started.")
+	}
+
+	for {
+		aon.SetAlarm(time.Now().Truncate(5*time.Second).Add(5*time.Second), false)
+		aon.WaitAlarm(-1)
+		fmt.Println(time.Now().Format(time.DateTime), aon.Time().Format(time.DateTime))
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Gpout outputs the XOSC clock divided by 12 on the GP21 pin and periodically
+// prints the frequencies of the clock sources measured by the frequency
+// counter.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx  = pins.GP0
+		conRx  = pins.GP1
+		clkOut = pins.GP21
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	hz := clock.GPOUT0.Setup(clock.XOSC, 12<<16, int(clkOut))
+	fmt.Printf("\nGPOUT0: %d Hz\n", hz)
+
+	srcs := []struct {
+		name string
+		src  clock.Source
+	}{
+		{"XOSC", clock.XOSC},
+		{"ROSC", clock.ROSC},
+		{"LPOSC", clock.LPOSC},
+		{"PLL_SYS", clock.PLL_SYS},
+		{"PLL_USB", clock.PLL_USB},
+		{"CLK_SYS", clock.CLK_SYS},
+	}
+	for {
+		for _, s := range srcs {
+			fmt.Printf("%-8s %10d Hz\n", s.name, clock.Measure(s.src))
+		}
+		fmt.Println()
+		time.Sleep(2 * time.Second)
+	}
+}
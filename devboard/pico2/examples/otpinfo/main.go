@@ -0,0 +1,48 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Otpinfo prints the device IDs, the boot configuration and the page locks
+// read from OTP. It doesn't write anything to OTP.
+package main
+
+import (
+	"fmt"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/otp"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	fmt.Println()
+	chipID, err := otp.ChipID()
+	fmt.Printf("CHIPID:      %016x %v\n", chipID, err)
+	randID, err := otp.RandID()
+	fmt.Printf("RANDID:      %x %v\n", randID, err)
+	crit1, err := otp.ReadCrit1()
+	fmt.Printf("CRIT1:       %06x %v\n", crit1, err)
+	flags0, err := otp.ReadBootFlags0()
+	fmt.Printf("BOOT_FLAGS0: %06x %v\n", flags0, err)
+	flags1, err := otp.ReadBootFlags1()
+	fmt.Printf("BOOT_FLAGS1: %06x %v\n", flags1, err)
+
+	fmt.Println("\npage  secure        non-secure")
+	for pg := 0; pg < otp.NumPages; pg++ {
+		s, ns := otp.SoftLock(pg)
+		if s != otp.ReadWrite || ns != otp.ReadWrite {
+			fmt.Printf("%4d  %-12v  %v\n", pg, s, ns)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Poweroff shows how to power off the chip and wake it up using the always-on
+// timer alarm or the button connected to GP15. The number of power cycles is
+// kept in a POWMAN scratch register.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/leds"
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/aon"
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/power"
+	"github.com/embeddedgo/pico/hal/powman"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+		btn   = pins.GP15
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	scratch := &powman.POWMAN().SCRATCH[0]
+	wakeup := power.LastWakeup()
+	n := scratch.Load()
+	if wakeup&power.WakeupReset != 0 {
+		n = 0
+	}
+	scratch.Store(n + 1)
+	fmt.Printf("\nWakeup: %07b, power cycles: %d\n", wakeup, n)
+
+	if !aon.Running() {
+		aon.UseLPOSC(32768)
+		aon.Start()
+	}
+
+	for i := 0; i < 6; i++ {
+		leds.User.Toggle()
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	fmt.Println("Power off for 5 seconds (or press the button).")
+	time.Sleep(10 * time.Millisecond) // let the UART send the message
+	btn.Setup(iomux.InpEn | iomux.PullUp | iomux.Schmitt)
+	power.SetWakeupPin(0, btn, true, false)
+	aon.SetAlarmMillis(aon.Millis()+5000, true)
+	if err := power.PowerOff(0); err != nil {
+		fmt.Println(err)
+	}
+}
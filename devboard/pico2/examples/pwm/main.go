@@ -0,0 +1,51 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Pwm smoothly changes the brightness of the on-board LED and generates two
+// synchronized, complementary 25 kHz signals on GP2 and GP4.
+package main
+
+import (
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/leds"
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/pwm"
+)
+
+func main() {
+	p := pwm.PWM(0)
+	p.SetReset(true)
+	p.SetReset(false)
+
+	// On-board LED.
+	led := p.UsePin(leds.User.Pin())
+	ls := led.Slice()
+	ls.Setup(0)
+	ls.SetFreq(1e3)
+	ls.Enable()
+
+	// Two different slices started in sync.
+	c0 := p.UsePin(pins.GP2)
+	c1 := p.UsePin(pins.GP4)
+	for _, c := range []pwm.Channel{c0, c1} {
+		s := c.Slice()
+		s.Setup(pwm.PH_CORRECT)
+		s.SetFreq(25e3)
+		c.SetDuty(1, 3)
+	}
+	c1.SetInvert(true)
+	p.Enable(c0.Slice().Mask() | c1.Slice().Mask())
+
+	for {
+		for i := 0; i <= 100; i++ {
+			led.SetDuty(i*i, 100*100)
+			time.Sleep(10 * time.Millisecond)
+		}
+		for i := 100; i >= 0; i-- {
+			led.SetDuty(i*i, 100*100)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Pwmcapture generates a PWM signal on GP2 and measures its frequency and duty
+// cycle on GP5. Connect GP2 to GP5 to run this example.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/pwm"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+		out   = pins.GP2
+		in    = pins.GP5 // must be a B pin
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	p := pwm.PWM(0)
+	p.SetReset(true)
+	p.SetReset(false)
+
+	c := p.UsePin(out)
+	s := c.Slice()
+	s.Setup(0)
+	s.Enable()
+
+	for hz := 100; hz <= 1e6; hz *= 10 {
+		fmt.Println("set freq:", s.SetFreq(hz), "Hz")
+		for duty := 1; duty < 4; duty++ {
+			c.SetDuty(duty, 4)
+			f, err := p.MeasureFreq(in, 100*time.Millisecond)
+			if err != nil {
+				fmt.Println(err)
+			}
+			d, err := p.MeasureDuty(in, 20*time.Millisecond)
+			if err != nil {
+				fmt.Println(err)
+			}
+			fmt.Printf("  measured: %d Hz, duty: %.3f\n", f, d)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Reboot shows how to reboot the chip in different ways from the program.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/bootrom"
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+	"github.com/embeddedgo/pico/hal/watchdog"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+		led   = int(iomux.P25) // the onboard LED as the BOOTSEL activity LED
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	fmt.Println("\nReset reason:", watchdog.Reason())
+
+	s := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("r: reboot, b: BOOTSEL, p N: boot partition N > ")
+		if !s.Scan() {
+			continue
+		}
+		var (
+			cmd string
+			n   int
+			err error
+		)
+		fmt.Sscan(s.Text(), &cmd, &n)
+		switch cmd {
+		case "r":
+			bootrom.Reboot(100 * time.Millisecond)
+		case "b":
+			err = bootrom.RebootBootsel(0, led, 100*time.Millisecond)
+		case "p":
+			err = bootrom.RebootPartition(n, 100*time.Millisecond)
+		default:
+			continue
+		}
+		fmt.Println("error:", err)
+	}
+}
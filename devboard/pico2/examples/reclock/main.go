@@ -0,0 +1,42 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Reclock changes the SYS clock frequency at runtime. The UART console keeps
+// working because its driver recalculates the baudrate divider when notified
+// about the PERI clock change.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	maxHz := clock.SYS.Freq()
+	for {
+		for _, hz := range []int64{maxHz, maxHz / 2, 24e6, 6e6} {
+			actual := clock.SetSys(hz)
+			fmt.Printf(
+				"SYS: %d Hz, PERI: %d Hz, baudrate: %d\n",
+				actual, clock.PERI.Freq(), uart0.Driver().Baudrate(),
+			)
+			time.Sleep(2 * time.Second)
+		}
+	}
+}
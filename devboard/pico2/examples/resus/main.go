@@ -0,0 +1,62 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Resus enables the SYS clock supervision and periodically forces the RESUS
+// event. After every event it prints the clock frequencies, restores the
+// original clock configuration and reboots the chip using the watchdog every
+// third event to show that the events are recorded across reboots.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/resus"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+	"github.com/embeddedgo/pico/hal/watchdog"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	fmt.Printf(
+		"\nReset reason: %v, RESUS events before reboot: %d\n",
+		watchdog.Reason(), resus.Recorded(),
+	)
+	resus.Enable(0, true)
+
+	for {
+		time.Sleep(2 * time.Second)
+		resus.Force()
+		if !resus.Wait(time.Second) {
+			fmt.Println("no RESUS event")
+			continue
+		}
+		fmt.Printf(
+			"RESUS #%d: SYS: %d Hz, PERI: %d Hz\n",
+			resus.Count(), clock.SYS.Freq(), clock.PERI.Freq(),
+		)
+		if err := resus.Relock(); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("relocked: SYS: %d Hz\n", clock.SYS.Freq())
+		if resus.Count()%3 == 0 {
+			fmt.Println("reboot...")
+			time.Sleep(10 * time.Millisecond)
+			watchdog.Reboot()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Secboot reports whether the secure boot is enabled and prints the boot key
+// fingerprints stored in OTP. Compare them with the output of the
+// `picosign -otp -key KEY` command to check which key is programmed in which
+// slot. It doesn't write anything to OTP.
+package main
+
+import (
+	"fmt"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/otp"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	fmt.Println()
+	enabled, keys, err := otp.SecureBoot()
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	if enabled {
+		fmt.Println("Secure boot: enabled")
+	} else {
+		fmt.Println("Secure boot: disabled")
+	}
+	for n := 0; n < otp.NumBootKeys; n++ {
+		rows, err := otp.BootKey(n)
+		if err != nil {
+			fmt.Printf("BOOTKEY%d: %v\n", n, err)
+			continue
+		}
+		state := "invalid"
+		if keys>>uint(n)&1 != 0 {
+			state = "valid"
+		}
+		fmt.Printf("BOOTKEY%d: ", n)
+		for _, v := range rows {
+			fmt.Printf("%02x%02x", byte(v), byte(v>>8))
+		}
+		fmt.Println(" " + state)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Sha256 compares the hardware SHA-256 accelerator with the software
+// implementation from the crypto/sha256 package.
+package main
+
+import (
+	swsha256 "crypto/sha256"
+	"fmt"
+	"hash"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/dma"
+	"github.com/embeddedgo/pico/hal/sha256"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func measure(name string, h hash.Hash, data []byte) {
+	t := time.Now()
+	h.Write(data)
+	sum := h.Sum(nil)
+	fmt.Printf("%-6s %x %v\n", name, sum, time.Since(t))
+}
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	data := make([]byte, 64*1024+13)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	fmt.Println()
+	measure("soft", swsha256.New(), data)
+	measure("cpu", sha256.New(), data)
+	sha256.UseDMA(dma.DMA(0).AllocChannel())
+	measure("dma", sha256.New(), data)
+	measure("dma-u", sha256.New(), data[1:]) // unaligned, byte transfers
+	measure("soft-u", swsha256.New(), data[1:])
+}
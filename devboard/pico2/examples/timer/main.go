@@ -0,0 +1,50 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Timer shows how to use the TIMER0 alarms. A periodic alarm counts ticks in
+// the interrupt handler while the main loop sleeps using Timer.SleepUntil and
+// prints the sleep error and the number of ticks.
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/timer/timer0"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+var ticks atomic.Uint32
+
+//go:nosplit
+func tick() {
+	ticks.Add(1)
+}
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	t := timer0.Timer()
+	a := t.AllocAlarm()
+	a.SetPeriodic(time.Millisecond, tick)
+
+	next := t.Now()
+	for {
+		next += 500_000 // µs
+		t.SleepUntil(next)
+		late := t.Now() - next
+		fmt.Printf("ticks: %d, late: %d µs\n", ticks.Load(), late)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Trng prints random numbers generated by the true random number generator,
+// directly and using the crypto/rand package.
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/trng"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	trng.InstallCryptoRand()
+
+	var buf [16]byte
+	for {
+		if _, err := trng.Read(buf[:]); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("trng: %x\n", buf)
+		}
+		fmt.Println("rand:", rand.Text())
+		time.Sleep(time.Second)
+	}
+}
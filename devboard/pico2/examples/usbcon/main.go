@@ -0,0 +1,39 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Usbcon shows how to use the USB CDC-ACM virtual serial port as the system
+// console.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/embeddedgo/pico/hal/system/console/usbcon"
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/usb0"
+)
+
+func main() {
+	usbcon.Setup(usb0.Device(), &usb.DeviceInfo{
+		VendorID:     0x2e8a, // Raspberry Pi
+		ProductID:    0x000a, // Pico SDK CDC UART, use your own PID
+		Release:      0x0100,
+		Manufacturer: "Embedded Go",
+		Product:      "Pico 2 console",
+		Serial:       "0001",
+		MaxPower:     100,
+	}, "USB console")
+
+	s := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("Enter your name: ")
+		if !s.Scan() {
+			fmt.Println(s.Err())
+			continue
+		}
+		fmt.Printf("Hello %s!\n", s.Text())
+	}
+}
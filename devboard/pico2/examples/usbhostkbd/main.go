@@ -0,0 +1,75 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Usbhostkbd reads an USB keyboard connected to the Pico USB port and prints
+// the typed characters on the UART console. Use an USB OTG adapter and power
+// the board through the VSYS pin so the keyboard is powered from VBUS.
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+	"github.com/embeddedgo/pico/hal/usb/hid"
+	"github.com/embeddedgo/pico/hal/usb/hidhost"
+	"github.com/embeddedgo/pico/hal/usb/usb0"
+)
+
+const keyCapsLock = 0x39
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	host := usb0.Host()
+	host.Setup()
+
+	for {
+		fmt.Println("Waiting for a keyboard...")
+		host.WaitConnect(-1)
+		dev, err := host.Enumerate()
+		if err != nil {
+			fmt.Println("enumeration:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		fmt.Printf("Device %04x:%04x\n", dev.VendorID(), dev.ProductID())
+		kbd, err := hidhost.NewKeyboard(dev)
+		if err != nil {
+			fmt.Println(err)
+			host.WaitDisconnect(-1)
+			continue
+		}
+		var leds byte
+		for ev := range kbd.Events() {
+			if ev.Down && ev.Key == keyCapsLock {
+				leds ^= hid.CapsLock
+				kbd.SetLEDs(leds)
+			}
+			c := ev.ASCII()
+			if c == 0 {
+				continue
+			}
+			if leds&hid.CapsLock != 0 && (c|0x20) >= 'a' && (c|0x20) <= 'z' {
+				c ^= 0x20
+			}
+			if c == '\r' {
+				c = '\n'
+			}
+			os.Stdout.Write([]byte{c})
+		}
+		fmt.Println("\nDisconnected")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Usbhostmsc reads the partition table of an USB flash drive connected to the
+// Pico USB port and prints it on the UART console. Use an USB OTG adapter and
+// power the board through the VSYS pin so the drive is powered from VBUS.
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+	"github.com/embeddedgo/pico/hal/usb/mschost"
+	"github.com/embeddedgo/pico/hal/usb/usb0"
+)
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	host := usb0.Host()
+	host.Setup()
+
+	for {
+		fmt.Println("Waiting for a flash drive...")
+		host.WaitConnect(-1)
+		dev, err := host.Enumerate()
+		if err != nil {
+			fmt.Println("enumeration:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		fmt.Printf("Device %04x:%04x\n", dev.VendorID(), dev.ProductID())
+		disk, err := mschost.NewDisk(dev)
+		if err != nil {
+			fmt.Println(err)
+			host.WaitDisconnect(-1)
+			continue
+		}
+		bs := disk.BlockSize()
+		fmt.Printf(
+			"%s %s: %d blocks of %d B (%d MiB)\n",
+			disk.Vendor(), disk.Product(), disk.NumBlocks(), bs,
+			disk.NumBlocks()*int64(bs)>>20,
+		)
+		mbr := make([]byte, bs)
+		if err := disk.ReadBlocks(0, mbr); err != nil {
+			fmt.Println("read:", err)
+		} else if mbr[510] != 0x55 || mbr[511] != 0xaa {
+			fmt.Println("no MBR partition table")
+		} else {
+			le := binary.LittleEndian
+			for i := 0; i < 4; i++ {
+				e := mbr[446+16*i:]
+				if e[4] == 0 {
+					continue
+				}
+				fmt.Printf(
+					"partition %d: type 0x%02x, start %d, size %d\n",
+					i+1, e[4], le.Uint32(e[8:]), le.Uint32(e[12:]),
+				)
+			}
+		}
+		host.WaitDisconnect(-1)
+		fmt.Println("Disconnected")
+	}
+}
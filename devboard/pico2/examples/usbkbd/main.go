@@ -0,0 +1,95 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Usbkbd forwards the key presses from the M5Stack CardKB I2C keyboard to the
+// USB host as USB keyboard keystrokes. The device is a composite one: it also
+// provides the USB console.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/i2c"
+	"github.com/embeddedgo/pico/hal/i2c/i2c0dma"
+	"github.com/embeddedgo/pico/hal/system/console/usbcon"
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/hid"
+	"github.com/embeddedgo/pico/hal/usb/usb0"
+)
+
+// CardKB codes of the arrow keys.
+const (
+	cardLeft  = 0xb4
+	cardUp    = 0xb5
+	cardDown  = 0xb6
+	cardRight = 0xb7
+)
+
+func main() {
+	// Used IO pins
+	const (
+		sda = pins.GP8
+		scl = pins.GP9
+	)
+
+	// USB console and keyboard
+	kbd := hid.NewKeyboard("CardKB")
+	dev := usb0.Device()
+	usbcon.Setup(dev, &usb.DeviceInfo{
+		VendorID:     0x2e8a, // Raspberry Pi
+		ProductID:    0x000a, // use your own PID
+		Release:      0x0100,
+		Manufacturer: "Embedded Go",
+		Product:      "CardKB to USB",
+		MaxPower:     100,
+	}, "USB console", kbd)
+
+	// I2C0
+	i2cm := i2c0dma.Master()
+	i2cm.UsePin(sda, i2c.SDA)
+	i2cm.UsePin(scl, i2c.SCL)
+	i2cm.Setup(100e3)
+
+	// M5Stack CardKB I2C keyboard
+	kb := i2cm.NewConn(0x5F)
+
+	for {
+		c, err := kb.ReadByte()
+		kb.Close()
+		if err != nil {
+			fmt.Println("CardKB error:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		if c == 0 {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		var mod, key byte
+		switch c {
+		case cardLeft:
+			key = hid.KeyLeft
+		case cardUp:
+			key = hid.KeyUp
+		case cardDown:
+			key = hid.KeyDown
+		case cardRight:
+			key = hid.KeyRight
+		default:
+			mod, key = hid.ASCIIKey(c)
+		}
+		if key == 0 {
+			fmt.Printf("unsupported CardKB code: %#x\n", c)
+			continue
+		}
+		if dev.Config() == 0 {
+			continue
+		}
+		if err := kbd.TypeKey(mod, key); err != nil {
+			fmt.Println("USB error:", err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Usbmsc exposes a RAM disk to the USB host as a mass storage device. The host
+// sees an unformatted drive that can be formatted (e.g. as FAT) and used until
+// the next reset. The device is a composite one: it also provides the USB
+// console that periodically prints the number of non-empty disk sectors.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/system/console/usbcon"
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/msc"
+	"github.com/embeddedgo/pico/hal/usb/usb0"
+)
+
+var disk [128 * 1024]byte
+
+func main() {
+	bd := msc.NewRAMDisk(disk[:])
+
+	// To keep the data across resets use a region of the flash instead, e.g.
+	// the last MiB of the 4 MiB flash: bd := msc.NewFlashDisk(3<<20, 1<<20).
+	// See bootrom.FlashErase for the restrictions.
+
+	drive := msc.New("Pico 2 drive", bd)
+	usbcon.Setup(usb0.Device(), &usb.DeviceInfo{
+		VendorID:     0x2e8a, // Raspberry Pi
+		ProductID:    0x000a, // use your own PID
+		Release:      0x0100,
+		Manufacturer: "Embedded Go",
+		Product:      "Pico 2 drive",
+		Serial:       "0001",
+		MaxPower:     100,
+	}, "USB console", drive)
+
+	go drive.Serve()
+
+	for {
+		time.Sleep(5 * time.Second)
+		n := 0
+		for i := 0; i < len(disk); i += 512 {
+			for _, b := range disk[i : i+512] {
+				if b != 0 {
+					n++
+					break
+				}
+			}
+		}
+		fmt.Printf("%d of %d sectors used\n", n, len(disk)/512)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Watchdog shows how to use the watchdog with a background feeder. The main
+// loop pretends to hang after a few iterations so the health check fails and
+// the watchdog resets the chip. The number of resets is kept in a scratch
+// register.
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/embeddedgo/pico/devboard/pico2/board/pins"
+	"github.com/embeddedgo/pico/hal/system/console/uartcon"
+	"github.com/embeddedgo/pico/hal/uart"
+	"github.com/embeddedgo/pico/hal/uart/uart0"
+	"github.com/embeddedgo/pico/hal/watchdog"
+)
+
+var heartbeat atomic.Int64
+
+func main() {
+	// Used IO pins
+	const (
+		conTx = pins.GP0
+		conRx = pins.GP1
+	)
+
+	// Serial console
+	uartcon.Setup(uart0.Driver(), conRx, conTx, uart.Word8b, 115200, "UART0")
+
+	reason := watchdog.Reason()
+	resets := watchdog.Scratch0.Load()
+	if reason == watchdog.PowerOn {
+		resets = 0
+	} else {
+		resets++
+	}
+	watchdog.Scratch0.Store(resets)
+	fmt.Printf("\nReset reason: %v, watchdog resets: %d\n", reason, resets)
+
+	// The main loop is considered healthy if it was alive in the last second.
+	watchdog.Start(2 * time.Second)
+	heartbeat.Store(time.Now().UnixNano())
+	watchdog.StartFeeder(500*time.Millisecond, func() bool {
+		return time.Now().UnixNano()-heartbeat.Load() < int64(time.Second)
+	})
+
+	for i := 0; ; i++ {
+		fmt.Println("working", i)
+		if i == 5 {
+			fmt.Println("hanging...")
+			for {
+				time.Sleep(time.Second)
+			}
+		}
+		heartbeat.Store(time.Now().UnixNano())
+		time.Sleep(300 * time.Millisecond)
+	}
+}
@@ -17,7 +17,8 @@ import (
 	"github.com/embeddedgo/pico/hal/spi/spi1dma"
 )
 
-const Backlight = common.LED(iomux.P13) // TODO: PWM
+// Backlight allows to control the display brightness.
+var Backlight *common.Backlight
 
 var Display *pix.Display
 
@@ -53,6 +54,6 @@ func init() {
 
 	// Backlight
 
-	common.ConnectLED(Backlight.Pin(), iomux.D4mA, 0)
+	Backlight = common.ConnectBacklight(iomux.P13, 10e3)
 	Backlight.SetOn()
 }
@@ -0,0 +1,32 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc0
+
+import (
+	"embedded/rtos"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/adc"
+	"github.com/embeddedgo/pico/hal/dma"
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+)
+
+var driver *adc.Driver
+
+// Driver returns ready to use driver for the ADC.
+func Driver() *adc.Driver {
+	if driver == nil {
+		driver = adc.NewDriver(adc.ADC(0), dma.Channel{})
+		driver.Setup(500e3) // make this driver somewhat ready to use
+		irq.ADC_FIFO.Enable(rtos.IntPrioLow, system.NextCPU())
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _ADC_FIFO_Handler() { driver.ISR() }
+
+//go:linkname _ADC_FIFO_Handler IRQ35_Handler
@@ -0,0 +1,73 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc
+
+type CS uint32
+
+// CS bits
+const (
+	EN         CS = 0x01 << 0   //+ Power on ADC and enable its clock.
+	TS_EN      CS = 0x01 << 1   //+ Power on temperature sensor.
+	START_ONCE CS = 0x01 << 2   //+ Start a single conversion.
+	START_MANY CS = 0x01 << 3   //+ Continuously perform conversions.
+	READY      CS = 0x01 << 8   //+ ADC ready to start a new conversion.
+	ERR        CS = 0x01 << 9   //+ The most recent conversion encountered an error.
+	ERR_STICKY CS = 0x01 << 10  //+ Some past conversion encountered an error.
+	AINSEL     CS = 0x0F << 12  //+ Select analog mux input.
+	RROBIN     CS = 0x1FF << 16 //+ Round-robin sampling, 1 bit per channel.
+)
+
+// CS bit offsets
+const (
+	AINSELn = 12
+	RROBINn = 16
+)
+
+type FCS uint32
+
+// FCS bits
+const (
+	FEN     FCS = 0x01 << 0  //+ Write result to the FIFO after each conversion.
+	SHIFT   FCS = 0x01 << 1  //+ Right-shift FIFO results to be one byte in size.
+	FERR    FCS = 0x01 << 2  //+ Conversion error bit appears in the FIFO.
+	DREQ_EN FCS = 0x01 << 3  //+ Assert DMA requests when FIFO contains data.
+	EMPTY   FCS = 0x01 << 8  //+ FIFO empty.
+	FULL    FCS = 0x01 << 9  //+ FIFO full.
+	UNDER   FCS = 0x01 << 10 //+ FIFO has been underflowed.
+	OVER    FCS = 0x01 << 11 //+ FIFO has been overflowed.
+	LEVEL   FCS = 0x0F << 16 //+ Number of results waiting in the FIFO.
+	THRESH  FCS = 0x0F << 24 //+ DREQ/IRQ asserted when LEVEL >= THRESH.
+)
+
+// FCS bit offsets
+const (
+	LEVELn  = 16
+	THRESHn = 24
+)
+
+// FIFO bits
+const (
+	VAL  uint32 = 0xFFF << 0 //+ Conversion result.
+	SERR uint32 = 0x01 << 15 //+ This sample experienced a conversion error.
+)
+
+// DIV bits
+const (
+	FRAC uint32 = 0xFF << 0   //+ Fractional part of clock divisor.
+	INT  uint32 = 0xFFFF << 8 //+ Integer part of clock divisor.
+)
+
+// DIV bit offsets
+const (
+	FRACn = 0
+	INTn  = 8
+)
+
+type INTR uint32
+
+// INTR bits
+const (
+	FIFO INTR = 0x01 << 0 //+ FIFO level reached the threshold.
+)
@@ -0,0 +1,277 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc
+
+import (
+	"embedded/rtos"
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/dma"
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/system/clock"
+)
+
+// A Driver is a driver for the ADC peripheral.
+//
+// It supports single conversions (see Read) and the free-running mode in which
+// the ADC converts the selected input, or cycles through the selected inputs,
+// at the configured sample rate (see SetChannels, SetSampleRate, Capture). In
+// the free-running mode the conversion results are read from the ADC FIFO by
+// the ISR or by the DMA if the driver was created with a valid DMA channel.
+type Driver struct {
+	sync.Mutex // helps in case of concurent use of Driver (not used internally)
+
+	p *Periph
+
+	start uintptr
+	end   uintptr
+	done  rtos.Note
+
+	dma dma.Channel
+	din int
+}
+
+// NewDriver returns a new driver for p. If valid DMA channel is given, the DMA
+// will be used for bigger data transfers in the free-running mode.
+func NewDriver(p *Periph, dc dma.Channel) *Driver {
+	if dc.IsValid() {
+		dc.SetReadAddr(unsafe.Pointer(&p.FIFO))
+	}
+	return &Driver{p: p, dma: dc, din: int(system.NextCPU())}
+}
+
+// Periph returns the underlying ADC peripheral.
+func (d *Driver) Periph() *Periph {
+	return d.p
+}
+
+// Enable powers on the ADC and waits until it's ready for conversion.
+func (d *Driver) Enable() {
+	p := d.p
+	internal.AtomicSet(&p.CS, EN)
+	for p.CS.LoadBits(READY) == 0 {
+	}
+}
+
+// Disable powers off the ADC and the temperature sensor.
+func (d *Driver) Disable() {
+	internal.AtomicClear(&d.p.CS, EN|TS_EN)
+}
+
+// The number of ADC clock cycles required for a single conversion.
+const convCycles = 96
+
+// SampleRate returns the sample rate in the free-running mode.
+func (d *Driver) SampleRate() int {
+	adcHz := clock.ADC.Freq()
+	div := int64(d.p.DIV.Load())
+	if div < (convCycles-1)<<INTn {
+		return int(adcHz / convCycles)
+	}
+	return int(adcHz << INTn / (div + 1<<INTn))
+}
+
+// SetSampleRate sets the sample rate in the free-running mode. The maximum
+// sample rate is clock.ADC.Freq() / 96 (500 kS/s for the default 48 MHz ADC
+// clock). SetSampleRate returns the actual sample rate which may differ from
+// the requested one due to the limited resolution of the ADC clock divider.
+// It returns -1 if the requested sample rate is too low.
+func (d *Driver) SetSampleRate(rate int) (actual int) {
+	if rate <= 0 {
+		return -1
+	}
+	// The conversion period is 1 + INT + FRAC/256 ADC clock cycles.
+	div := clock.ADC.Freq()<<INTn/int64(rate) - 1<<INTn
+	if div < (convCycles-1)<<INTn {
+		div = 0 // back-to-back conversions
+	} else if div > int64(INT|FRAC) {
+		return -1
+	}
+	d.p.DIV.Store(uint32(div))
+	return d.SampleRate()
+}
+
+// Setup resets the underlying ADC peripheral, powers it on and sets the sample
+// rate used in the free-running mode (see SetSampleRate).
+func (d *Driver) Setup(sampleRate int) (actualRate int) {
+	p := d.p
+	p.SetReset(true)
+	p.SetReset(false)
+	d.Enable()
+	return d.SetSampleRate(sampleRate)
+}
+
+// SetChannels selects the ADC inputs for the free-running mode. The channel
+// first is converted first. If rrobin is zero only the channel first is
+// converted. Otherwise the ADC cycles through all the channels selected by the
+// rrobin bitmask (bit n selects channel n) in a round-robin fashion so the
+// consecutive samples in the Capture buffer correspond to the consecutive
+// selected channels, starting from first.
+func (d *Driver) SetChannels(first int, rrobin uint16) {
+	if uint(first) > 8 || rrobin >= 1<<9 {
+		panic("adc: bad channel")
+	}
+	p := d.p
+	internal.AtomicMod(
+		&p.CS, AINSEL|RROBIN, p.CS.Load(),
+		CS(first)<<AINSELn|CS(rrobin)<<RROBINn,
+	)
+}
+
+var (
+	ErrConv    = errors.New("adc: conversion error")
+	ErrOverrun = errors.New("adc: FIFO overrun")
+)
+
+// Read performs a single conversion of the channel ch and returns its 12-bit
+// result. It returns ErrConv if the conversion encountered an error. Read must
+// not be used concurently with Capture.
+func (d *Driver) Read(ch int) (val int, err error) {
+	if uint(ch) > 8 {
+		panic("adc: bad channel")
+	}
+	p := d.p
+	cs := p.CS.Load() &^ (START_ONCE | ERR_STICKY)
+	p.CS.Store(cs&^(AINSEL|RROBIN) | CS(ch)<<AINSELn | START_ONCE)
+	for p.CS.LoadBits(READY) == 0 {
+	}
+	st := p.CS.Load()
+	val = int(p.RESULT.Load())
+	p.CS.Store(cs) // restore the free-running mode channels
+	if st&ERR != 0 {
+		err = ErrConv
+	}
+	return
+}
+
+const (
+	fifoThr = 2 // FIFO threshold in the ISR mode
+	minDMA  = 8
+)
+
+// Capture starts the free-running mode and stores the consecutive conversion
+// results in buf until it's full. Next it stops the ADC and discards any excess
+// results. Capture returns the number of samples stored in buf. It stops
+// storing samples at the first one that encountered a conversion error and
+// returns ErrConv. It returns ErrOverrun if the conversion results were
+// produced faster than they could be read from the ADC FIFO (use DMA or reduce
+// the sample rate).
+func (d *Driver) Capture(buf []uint16) (n int, err error) {
+	if len(buf) == 0 {
+		return
+	}
+	ptr := unsafe.Pointer(unsafe.SliceData(buf))
+	if len(buf) < minDMA || !d.dma.IsValid() {
+		n = captureISR(d, ptr, len(buf))
+	} else {
+		captureDMA(d, ptr, len(buf))
+		n = len(buf)
+		for i, v := range buf {
+			if uint32(v)&SERR != 0 {
+				n = i
+				break
+			}
+		}
+	}
+	if stopCapture(d.p)&OVER != 0 {
+		err = ErrOverrun
+	} else if n != len(buf) {
+		err = ErrConv
+	}
+	return
+}
+
+func captureISR(d *Driver, ptr unsafe.Pointer, n int) int {
+	return _captureISR(d, uintptr(ptr), n)
+}
+
+//go:uintptrescapes
+func _captureISR(d *Driver, ptr uintptr, n int) int {
+	p := d.p
+	d.start = ptr
+	d.end = ptr + uintptr(n)*2
+	d.done.Clear() // memory barrier
+	p.FCS.Store(FEN | FERR | FCS(min(n, fifoThr))<<THRESHn | OVER | UNDER)
+	p.INTE.Store(FIFO)
+	internal.AtomicSet(&p.CS, START_MANY)
+	d.done.Sleep(-1)
+	return int(d.start-ptr) / 2
+}
+
+func captureDMA(d *Driver, ptr unsafe.Pointer, n int) {
+	_captureDMA(d, uintptr(ptr), n)
+}
+
+//go:uintptrescapes
+func _captureDMA(d *Driver, ptr uintptr, n int) {
+	p := d.p
+	d.done.Clear() // memory barrier
+	dc := d.dma
+	dc.ClearIRQ()
+	dc.SetWriteAddr(unsafe.Pointer(ptr))
+	dc.SetTransCount(n, dma.Normal)
+	dc.SetConfigTrig(dma.En|dma.S16b|dma.IncW|dma.ADC, dc)
+	dc.EnableIRQ(d.din)
+	p.FCS.Store(FEN | FERR | DREQ_EN | 1<<THRESHn | OVER | UNDER)
+	internal.AtomicSet(&p.CS, START_MANY)
+	d.done.Sleep(-1)
+}
+
+// stopCapture stops the free-running mode, drains and disables the FIFO. It
+// returns the state of the FIFO flags from before draining.
+func stopCapture(p *Periph) (fcs FCS) {
+	internal.AtomicClear(&p.CS, START_MANY)
+	for p.CS.LoadBits(READY) == 0 {
+	}
+	fcs = p.FCS.Load()
+	for p.FCS.LoadBits(EMPTY) == 0 {
+		p.FIFO.Load()
+	}
+	p.FCS.Store(OVER | UNDER) // disable FIFO and clear its error flags
+	return
+}
+
+// ISR is the interrupt handler that handles the data transfers from the ADC
+// FIFO scheduled by the Capture method.
+//
+//go:nosplit
+//go:nowritebarrierrec
+func (d *Driver) ISR() {
+	p := d.p
+	addr, end := d.start, d.end
+	for addr < end && p.FCS.LoadBits(EMPTY) == 0 {
+		v := p.FIFO.Load()
+		if v&SERR != 0 {
+			end = addr // stop at the first erroneous sample
+			break
+		}
+		*(*uint16)(unsafe.Pointer(addr)) = uint16(v)
+		addr += 2
+	}
+	d.start = addr
+	if addr >= end {
+		internal.AtomicClear(&p.CS, START_MANY)
+		p.INTE.Store(0)
+		d.done.Wakeup()
+		return
+	}
+	if n := (end - addr) / 2; n < fifoThr {
+		// Reduce the FIFO threshold to the size of the last chunk.
+		p.FCS.StoreBits(THRESH, FCS(n)<<THRESHn)
+	}
+}
+
+// DMAISR should be configured as a DMA interrupt handler if DMA is used.
+//
+//go:nosplit
+//go:nowritebarrierrec
+func (d *Driver) DMAISR() {
+	d.dma.DisableIRQ(d.din)
+	internal.AtomicClear(&d.p.CS, START_MANY)
+	d.done.Wakeup()
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/resets"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	CS     mmio.R32[CS]
+	RESULT mmio.U32
+	FCS    mmio.R32[FCS]
+	FIFO   mmio.U32
+	DIV    mmio.U32
+	INTR   mmio.R32[INTR]
+	INTE   mmio.R32[INTR]
+	INTF   mmio.R32[INTR]
+	INTS   mmio.R32[INTR]
+}
+
+// ADC returns the n-th instance of the ADC peripheral (RP2350 supports only
+// ADC 0).
+func ADC(n int) *Periph {
+	if n != 0 {
+		panic("wrong ADC number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.ADC_BASE))
+}
+
+// SetReset allows to assert/deassert the reset signal to the ADC peripheral.
+func (p *Periph) SetReset(assert bool) {
+	internal.SetReset(resets.ADC, assert)
+}
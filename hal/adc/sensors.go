@@ -0,0 +1,46 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc
+
+import "github.com/embeddedgo/pico/hal/internal"
+
+// VRef is the ADC reference voltage in millivolts assumed by the methods that
+// return voltage or temperature. It corresponds to the 3.3 V ADC_AVDD supply
+// used by most boards.
+var VRef = 3300
+
+// ReadMillivolts performs a single conversion of the channel ch and returns the
+// input voltage in millivolts (see VRef).
+func (d *Driver) ReadMillivolts(ch int) (mV int, err error) {
+	v, err := d.Read(ch)
+	return (v*VRef + 2048) >> 12, err
+}
+
+// EnableTempSensor enables or disables the bias source of the on-chip
+// temperature sensor. The sensor is disabled by default to save power.
+func (d *Driver) EnableTempSensor(en bool) {
+	if en {
+		internal.AtomicSet(&d.p.CS, TS_EN)
+	} else {
+		internal.AtomicClear(&d.p.CS, TS_EN)
+	}
+}
+
+// ReadTemp reads the on-chip temperature sensor and returns the die
+// temperature in degrees Celsius. It enables the temperature sensor if it's
+// disabled. The result is calculated using the formula from the datasheet:
+//
+//	T = 27 - (V - 0.706) / 0.001721
+//
+// The sensor is very sensitive to the reference voltage errors (1 mV of error
+// means about 0.6 °C).
+func (d *Driver) ReadTemp() (celsius float32, err error) {
+	if d.p.CS.LoadBits(TS_EN) == 0 {
+		d.EnableTempSensor(true)
+	}
+	v, err := d.Read(TempSensor())
+	volts := float32(v) * float32(VRef) / (1000 << 12)
+	return 27 - (volts-0.706)/0.001721, err
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adc
+
+import (
+	"embedded/mmio"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// qfn60 reports whether the chip is in the QFN60 package (RP2350A) using the
+// SYSINFO PACKAGE_SEL register.
+func qfn60() bool {
+	return (*mmio.U32)(unsafe.Pointer(mmap.SYSINFO_BASE+4)).LoadBits(1) != 0
+}
+
+// firstPin returns the IO pin connected to the ADC channel 0 and the number of
+// ADC channels connected to IO pins.
+func firstPin() (pin iomux.Pin, n int) {
+	if qfn60() {
+		return iomux.P26, 4
+	}
+	return iomux.P40, 8
+}
+
+// TempSensor returns the ADC channel connected to the on-chip temperature
+// sensor. It's the channel that follows the last channel connected to an IO
+// pin (4 in case of RP2350A, 8 in case of RP2350B).
+func TempSensor() int {
+	_, n := firstPin()
+	return n
+}
+
+// Pins returns the IO pins that can be used as analog inputs. The n-th pin in
+// the returned slice is connected to the n-th ADC channel.
+func (p *Periph) Pins() []iomux.Pin {
+	first, n := firstPin()
+	pins := make([]iomux.Pin, n)
+	for i := range pins {
+		pins[i] = first + iomux.Pin(i)
+	}
+	return pins
+}
+
+// UsePin is a helper function that can be used to configure an IO pin as an
+// analog input. It disconnects the pin from all digital peripherals, disables
+// its input and output buffers and pull resistors. UsePin returns the ADC
+// channel connected to the pin or -1 if the pin cannot be used as an analog
+// input. See also Periph.Pins.
+func (d *Driver) UsePin(pin iomux.Pin) (ch int) {
+	first, n := firstPin()
+	ch = int(pin - first)
+	if uint(ch) >= uint(n) {
+		return -1
+	}
+	pin.SetAltFunc(pin.AltFunc() &^ iomux.Func) // NULL function
+	pin.Setup(iomux.OutDis)
+	return ch
+}
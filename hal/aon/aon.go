@@ -0,0 +1,306 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package aon provides a driver for the always-on timer (AON timer) of the
+// Power Manager. The AON timer is a 64-bit millisecond counter that keeps
+// running in all low power states and can wake the chip up at the specified
+// time, so it can be used as a real-time clock.
+//
+// The AON timer isn't reset by the chip reset that follows the power-up from
+// a low power state, so the wall time can be restored by calling
+// SyncSystemTime after such reset.
+package aon
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"runtime"
+	"sync"
+	"time"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/powman"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/system/clock"
+)
+
+// The POWMAN registers are accessible only in the privileged mode. The
+// unexported functions below must be called in this mode.
+
+// Running reports whether the timer is running.
+func Running() bool {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := running()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return r
+}
+
+func running() bool {
+	return powman.POWMAN().TIMER.LoadBits(powman.RUN) != 0
+}
+
+// Start starts the timer. Configure the clock source before starting the timer
+// (see UseLPOSC, UseXOSC, UseGPIO1kHz).
+func Start() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	start()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+func start() {
+	internal.AtomicSet(&powman.POWMAN().TIMER, powman.PASSWD|powman.RUN)
+}
+
+// Stop stops the timer.
+func Stop() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	stop()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// stop stops the timer and reports whether it was running.
+func stop() bool {
+	r := running()
+	if r {
+		internal.AtomicClear(&powman.POWMAN().TIMER, powman.PASSWD|powman.RUN)
+	}
+	return r
+}
+
+func setFreq(intReg, fracReg *mmio.U32, hz int) {
+	intReg.Store(powman.PASSWD | uint32(hz/1000))
+	fracReg.Store(powman.PASSWD | uint32(hz%1000*65536/1000))
+}
+
+// UseLPOSC selects the low power oscillator as the source of the 1 kHz tick.
+// The hz is the LPOSC frequency (nominally 32768 Hz, but it can differ from
+// the nominal value by a few percent, so measure it if you need more
+// accuracy). The LPOSC keeps running in all power states.
+func UseLPOSC(hz int) {
+	p := powman.POWMAN()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := stop()
+	setFreq(&p.LPOSC_FREQ_KHZ_INT, &p.LPOSC_FREQ_KHZ_FRAC, hz)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.USE_LPOSC)
+	if r {
+		start()
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// UseXOSC selects the crystal oscillator as the source of the 1 kHz tick. The
+// XOSC is stopped in the low power states. The timer switches to LPOSC
+// automatically in this case and back to XOSC at wakeup, so configure the
+// LPOSC frequency (see UseLPOSC) before calling UseXOSC.
+func UseXOSC() {
+	p := powman.POWMAN()
+	// system.Setup clocks REF directly from XOSC.
+	hz := int(clock.REF.Freq())
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := stop()
+	setFreq(&p.XOSC_FREQ_KHZ_INT, &p.XOSC_FREQ_KHZ_FRAC, hz)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.USE_XOSC)
+	if r {
+		start()
+		for p.TIMER.LoadBits(powman.USING_XOSC) == 0 {
+		}
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+func extTimeRef(pin iomux.Pin) powman.EXT_TIME_REF {
+	var sel powman.EXT_TIME_REF
+	switch pin {
+	case iomux.P12:
+		sel = 0
+	case iomux.P20:
+		sel = 1
+	case iomux.P14:
+		sel = 2
+	case iomux.P22:
+		sel = 3
+	default:
+		panic("aon: pin can't be used as time reference")
+	}
+	pin.Setup(iomux.InpEn | iomux.OutDis | iomux.Schmitt)
+	return sel
+}
+
+// UseGPIO1kHz selects the 1 kHz signal on the pin as the source of the tick.
+// The pin must be one of P12, P14, P20, P22.
+func UseGPIO1kHz(pin iomux.Pin) {
+	p := powman.POWMAN()
+	sel := extTimeRef(pin)
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := stop()
+	p.EXT_TIME_REF.Store(powman.PASSWD | sel)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.USE_GPIO_1KHZ)
+	if r {
+		start()
+		for p.TIMER.LoadBits(powman.USING_GPIO_1KHZ) == 0 {
+		}
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// UseGPIO1Hz selects the 1 Hz signal on the pin (e.g. the PPS output of a GPS
+// receiver or an external RTC) as the reference for the second counter. The
+// millisecond counter continues to use the LPOSC or XOSC tick but it is
+// synchronised to the 1 Hz reference every second. The pin must be one of
+// P12, P14, P20, P22.
+func UseGPIO1Hz(pin iomux.Pin) {
+	p := powman.POWMAN()
+	sel := extTimeRef(pin)
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := stop()
+	p.EXT_TIME_REF.Store(powman.PASSWD | sel)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.USE_GPIO_1HZ)
+	if r {
+		start()
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Millis returns the current value of the timer in milliseconds.
+func Millis() int64 {
+	p := powman.POWMAN()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	h := p.READ_TIME_UPPER.Load()
+	var l uint32
+	for {
+		l = p.READ_TIME_LOWER.Load()
+		h1 := p.READ_TIME_UPPER.Load()
+		if h1 == h {
+			break
+		}
+		h = h1
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return int64(h)<<32 | int64(l)
+}
+
+func store64(r *[4]mmio.U32, v int64) {
+	r[0].Store(powman.PASSWD | uint32(v>>48)&0xffff)
+	r[1].Store(powman.PASSWD | uint32(v>>32)&0xffff)
+	r[2].Store(powman.PASSWD | uint32(v>>16)&0xffff)
+	r[3].Store(powman.PASSWD | uint32(v)&0xffff)
+}
+
+// SetMillis sets the timer value in milliseconds.
+func SetMillis(ms int64) {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := stop()
+	store64(&powman.POWMAN().SET_TIME, ms)
+	if r {
+		start()
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Time returns the time kept by the timer, assuming it counts milliseconds
+// from the Unix epoch (see SetTime).
+func Time() time.Time {
+	return time.UnixMilli(Millis())
+}
+
+// SetTime sets the timer to t (in milliseconds since the Unix epoch) and sets
+// the system time, so time.Now returns the correct wall time.
+func SetTime(t time.Time) error {
+	SetMillis(t.UnixMilli())
+	return rtos.SetSystemTime(t)
+}
+
+// SyncSystemTime sets the system time to the time kept by the timer. Use it
+// after the reset to restore the wall time returned by time.Now.
+func SyncSystemTime() error {
+	return rtos.SetSystemTime(Time())
+}
+
+var (
+	irqOnce sync.Once
+	alarm   rtos.Note
+)
+
+// SetAlarm sets the alarm to the time t (see SetTime). If wakeup is true the
+// alarm wakes the chip up from a low power state. Use WaitAlarm to wait for
+// the alarm.
+func SetAlarm(t time.Time, wakeup bool) {
+	SetAlarmMillis(t.UnixMilli(), wakeup)
+}
+
+// SetAlarmMillis works like SetAlarm but takes the timer value in
+// milliseconds.
+func SetAlarmMillis(ms int64, wakeup bool) {
+	irqOnce.Do(func() {
+		irq.POWMAN_TIMER.Enable(rtos.IntPrioLow, system.NextCPU())
+	})
+	p := powman.POWMAN()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	cancelAlarm()
+	store64(&p.ALARM_TIME, ms)
+	alarm.Clear()
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.ALARM) // clear
+	if wakeup {
+		internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.PWRUP_ON_ALARM)
+	}
+	internal.AtomicSet(&p.INTE, powman.PASSWD|powman.INT_TIMER)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.ALARM_ENAB)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// CancelAlarm disables the alarm.
+func CancelAlarm() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	cancelAlarm()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+func cancelAlarm() {
+	p := powman.POWMAN()
+	internal.AtomicClear(&p.TIMER, powman.PASSWD|powman.ALARM_ENAB|powman.PWRUP_ON_ALARM)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.ALARM) // clear
+}
+
+// WaitAlarm waits for the alarm. It reports whether the alarm fired before the
+// timeout. The negative timeout means no timeout.
+func WaitAlarm(timeout time.Duration) bool {
+	return alarm.Sleep(timeout)
+}
+
+//go:interrupthandler
+func _POWMAN_TIMER_Handler() {
+	p := powman.POWMAN()
+	// The alarm condition persists until the alarm is disabled.
+	internal.AtomicClear(&p.INTE, powman.PASSWD|powman.INT_TIMER)
+	internal.AtomicClear(&p.TIMER, powman.PASSWD|powman.ALARM_ENAB)
+	internal.AtomicSet(&p.TIMER, powman.PASSWD|powman.ALARM)
+	alarm.Wakeup()
+}
+
+//go:linkname _POWMAN_TIMER_Handler IRQ45_Handler
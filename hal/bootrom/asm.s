@@ -0,0 +1,43 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// The ROM functions use the stack of the calling goroutine. The 1 KiB frame
+// reserved by the trampoline guarantees that they do not overwrite anything.
+// The SP is moved to the top of the frame (aligned to 8 bytes) before the
+// call and restored after it.
+
+// func call(fn, a0, a1, a2, a3 uintptr) uintptr
+TEXT ·call(SB),$1024-24
+	MOVW  fn+0(FP), R12
+	MOVW  a0+4(FP), R0
+	MOVW  a1+8(FP), R1
+	MOVW  a2+12(FP), R2
+	MOVW  a3+16(FP), R3
+	MOVW  R13, R4
+	ADD   $1024, R13
+	BIC   $7, R13  // AAPCS requires 8-byte aligned stack
+	BL    (R12)
+	MOVW  R4, R13
+	MOVW  R0, ret+20(FP)
+	RET
+
+// func callNoInt(fn, a0, a1, a2, a3 uintptr) uintptr
+TEXT ·callNoInt(SB),$1024-24
+	MOVW  fn+0(FP), R12
+	MOVW  a0+4(FP), R0
+	MOVW  a1+8(FP), R1
+	MOVW  a2+12(FP), R2
+	MOVW  a3+16(FP), R3
+	MOVW  R13, R4
+	MOVW  PRIMASK, R5
+	CPSID
+	ADD   $1024, R13
+	BIC   $7, R13  // AAPCS requires 8-byte aligned stack
+	BL    (R12)
+	MOVW  R4, R13
+	MOVW  R5, PRIMASK
+	MOVW  R0, ret+20(FP)
+	RET
@@ -0,0 +1,95 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bootrom provides access to the functions exported by the RP2350
+// boot ROM.
+package bootrom
+
+import (
+	"strconv"
+	"unsafe"
+)
+
+// Code returns the two character code that identifies a boot ROM function or
+// data in the ROM lookup table.
+func Code(c1, c2 byte) uint32 {
+	return uint32(c1) | uint32(c2)<<8
+}
+
+const funcArmSec = 0x0004 // lookup table flag
+
+// Func returns the address of the boot ROM function identified by code (see
+// Code) that can be called from the Arm Secure state or 0 if there is no such
+// function.
+func Func(code uint32) uintptr {
+	// The ROM contains a 16-bit pointer to the lookup function at 0x16.
+	lookup := uintptr(*(*uint16)(unsafe.Pointer(uintptr(0x16))))
+	return call(lookup, uintptr(code), funcArmSec, 0, 0)
+}
+
+// call calls the ROM function fn using the AAPCS calling convention. The
+// function runs on the goroutine stack and can use at most 1 KiB of it.
+//
+//go:uintptrescapes
+func call(fn, a0, a1, a2, a3 uintptr) uintptr
+
+// callNoInt works like call but with interrupts disabled on the current CPU.
+//
+//go:uintptrescapes
+func callNoInt(fn, a0, a1, a2, a3 uintptr) uintptr
+
+// An Error is an error code returned by a boot ROM function.
+type Error int32
+
+const (
+	ErrNotPermitted       Error = -4
+	ErrInvalidArg         Error = -5
+	ErrInvalidAddress     Error = -10
+	ErrBadAlignment       Error = -11
+	ErrInvalidState       Error = -12
+	ErrBufferTooSmall     Error = -13
+	ErrPreconditionNotMet Error = -14
+	ErrModifiedData       Error = -15
+	ErrInvalidData        Error = -16
+	ErrNotFound           Error = -17
+	ErrUnsupportedModif   Error = -18
+	ErrLockRequired       Error = -19
+	ErrNotImplemented     Error = -100 // the function isn't in the ROM
+)
+
+var errStr = [...]string{
+	-ErrNotPermitted:       "not permitted",
+	-ErrInvalidArg:         "invalid argument",
+	-ErrInvalidAddress:     "invalid address",
+	-ErrBadAlignment:       "bad alignment",
+	-ErrInvalidState:       "invalid state",
+	-ErrBufferTooSmall:     "buffer too small",
+	-ErrPreconditionNotMet: "precondition not met",
+	-ErrModifiedData:       "modified data",
+	-ErrInvalidData:        "invalid data",
+	-ErrNotFound:           "not found",
+	-ErrUnsupportedModif:   "unsupported modification",
+	-ErrLockRequired:       "lock required",
+}
+
+func (e Error) Error() string {
+	s := ""
+	if e == ErrNotImplemented {
+		s = "function not implemented"
+	} else if -e > 0 && int(-e) < len(errStr) {
+		s = errStr[-e]
+	}
+	if s == "" {
+		s = "error " + strconv.Itoa(int(e))
+	}
+	return "bootrom: " + s
+}
+
+// result converts the value returned by a ROM function to error.
+func result(r uintptr) error {
+	if int32(r) < 0 {
+		return Error(int32(r))
+	}
+	return nil
+}
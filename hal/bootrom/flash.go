@@ -0,0 +1,79 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bootrom
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"unsafe"
+)
+
+const (
+	FlashSectorSize = 4096 // erase granularity
+	FlashPageSize   = 256  // program granularity
+
+	// FlashBase is the address of the flash memory in the XIP address space
+	// (cached access).
+	FlashBase = 0x10000000
+)
+
+// flash_op flags (CFLASH_ASPACE bits 0-1, CFLASH_SECLEVEL bits 8-9, CFLASH_OP
+// bits 16-18)
+const (
+	cflashStorage = 0 << 0 // addresses are offsets from the start of flash
+	cflashSecure  = 1 << 8
+	cflashErase   = 0 << 16
+	cflashProgram = 1 << 16
+	cflashRead    = 2 << 16
+)
+
+//go:uintptrescapes
+func flashOp(op uint32, off int, buf uintptr, n int) error {
+	fn := Func(Code('F', 'O'))
+	if fn == 0 {
+		return ErrNotImplemented
+	}
+	flags := uintptr(cflashSecure | op | cflashStorage)
+	// The interrupts can be disabled only in the privileged mode and the ROM
+	// function reconfigures the privileged-only XIP_CTRL and XIP_QMI.
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := callNoInt(fn, flags, uintptr(off), uintptr(n), buf)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return result(r)
+}
+
+// FlashErase erases n bytes of the external flash starting at offset off.
+// Both off and n must be multiples of FlashSectorSize.
+//
+// The whole operation is performed by the boot ROM with the XIP temporarily
+// disabled and the interrupts disabled on the current CPU. The other CPU must
+// not access the flash in the meantime (including instruction fetches) unless
+// the accessed data is in the XIP cache. Keep in mind that the program code
+// resides in flash, so use it for short, infrequent operations only.
+func FlashErase(off, n int) error {
+	return flashOp(cflashErase, off, 0, n)
+}
+
+// FlashProgram programs the external flash starting at offset off with the
+// data from p. Both off and len(p) must be multiples of FlashPageSize. The
+// programmed area must be erased before. See FlashErase for the restrictions.
+func FlashProgram(off int, p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	return flashOp(cflashProgram, off, uintptr(unsafe.Pointer(&p[0])), len(p))
+}
+
+// FlashRead reads len(p) bytes of the external flash starting at offset off
+// using the serial flash commands, bypassing the XIP cache. The cached data
+// can be read directly from the FlashBase+off address.
+func FlashRead(off int, p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	return flashOp(cflashRead, off, uintptr(unsafe.Pointer(&p[0])), len(p))
+}
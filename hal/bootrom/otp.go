@@ -0,0 +1,37 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bootrom
+
+import "unsafe"
+
+// otp_access command flags
+const (
+	otpIsWrite = 1 << 16
+	otpIsECC   = 1 << 17
+)
+
+// OTPAccess reads or writes the OTP rows starting from row using the boot ROM
+// otp_access function. In the ECC mode every row holds 2 bytes of p, otherwise
+// every row holds 4 bytes of p (the 24-bit raw row value, little-endian, the
+// most significant byte is zero). The length of p must be a multiple of the
+// row size. The boot ROM checks the page permissions and refuses to access
+// the locked pages.
+func OTPAccess(p []byte, row int, write, ecc bool) error {
+	if len(p) == 0 {
+		return nil
+	}
+	fn := Func(Code('O', 'A'))
+	if fn == 0 {
+		return ErrNotImplemented
+	}
+	cmd := uintptr(row) & 0xffff
+	if write {
+		cmd |= otpIsWrite
+	}
+	if ecc {
+		cmd |= otpIsECC
+	}
+	return result(call(fn, uintptr(unsafe.Pointer(&p[0])), uintptr(len(p)), cmd, 0))
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bootrom
+
+import "unsafe"
+
+// get_partition_table_info flags
+const (
+	ptInfoLocationAndFlags = 0x0010
+	ptInfoSinglePartition  = 0x8000
+)
+
+// PartitionLocation returns the flash offset and the size of the partition n
+// from the partition table loaded by the boot ROM.
+func PartitionLocation(n int) (off, size int, err error) {
+	fn := Func(Code('G', 'P'))
+	if fn == 0 {
+		return 0, 0, ErrNotImplemented
+	}
+	var buf [3]uint32
+	flags := uintptr(ptInfoLocationAndFlags | ptInfoSinglePartition | n<<24)
+	r := call(fn, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), flags, 0)
+	if err = result(r); err != nil {
+		return
+	}
+	if r < 3 || buf[0]&ptInfoLocationAndFlags == 0 {
+		return 0, 0, ErrNotFound
+	}
+	first := int(buf[1] & 0x1fff)
+	last := int(buf[1] >> 13 & 0x1fff)
+	return first * FlashSectorSize, (last - first + 1) * FlashSectorSize, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bootrom
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/watchdog"
+)
+
+// reboot flags
+const (
+	rebootNormal      = 0x0
+	rebootBootsel     = 0x2
+	rebootFlashUpdate = 0x4
+	rebootNoReturn    = 0x100
+)
+
+// BOOTSEL mode options.
+type BootselFlags uint32
+
+const (
+	DisableMSD      BootselFlags = 0x01 // disable the USB mass storage interface
+	DisablePicoboot BootselFlags = 0x02 // disable the PICOBOOT interface
+	LEDActiveLow    BootselFlags = 0x10 // the activity LED is active low
+	ledPinSpecified BootselFlags = 0x20
+)
+
+// reboot calls the ROM reboot function. The delay is in milliseconds.
+func reboot(flags, delay, p0, p1 uintptr) error {
+	fn := Func(Code('R', 'B'))
+	if fn == 0 {
+		return ErrNotImplemented
+	}
+	// The reboot function uses the privileged-only watchdog and POWMAN.
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := call(fn, flags|rebootNoReturn, delay, p0, p1)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return result(r)
+}
+
+// Reboot reboots the chip after the specified delay (rounded down to ms). If
+// the boot ROM reboot function isn't available Reboot resets the chip
+// immediately using the watchdog. Reboot never returns.
+func Reboot(delay time.Duration) {
+	reboot(rebootNormal, uintptr(delay.Milliseconds()), 0, 0)
+	watchdog.Reboot()
+}
+
+// RebootBootsel reboots the chip into the BOOTSEL mode (USB bootloader) after
+// the specified delay. The flags allow to disable one of the USB interfaces.
+// If led >= 0 the GPIO pin with this number is used as the activity LED.
+// RebootBootsel returns only in case of error.
+func RebootBootsel(flags BootselFlags, led int, delay time.Duration) error {
+	if led >= 0 {
+		flags |= ledPinSpecified
+	} else {
+		led = 0
+	}
+	return reboot(rebootBootsel, uintptr(delay.Milliseconds()), uintptr(flags),
+		uintptr(led))
+}
+
+// RebootImage reboots the chip preferring the image (partition) that starts
+// at offset off of the flash. It is intended to try a new firmware version in
+// the A/B partition layout. The boot ROM still verifies the image and falls
+// back to the other partition if it isn't bootable. RebootImage returns only
+// in case of error.
+func RebootImage(off int, delay time.Duration) error {
+	return reboot(rebootFlashUpdate, uintptr(delay.Milliseconds()),
+		uintptr(FlashBase+off), 0)
+}
+
+// RebootPartition works like RebootImage but the image is specified by the
+// partition number in the partition table.
+func RebootPartition(n int, delay time.Duration) error {
+	off, _, err := PartitionLocation(n)
+	if err != nil {
+		return err
+	}
+	return RebootImage(off, delay)
+}
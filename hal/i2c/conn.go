@@ -58,6 +58,7 @@ func open(c *conn) {
 	c.open = true
 	d := c.d
 	d.Lock()
+	d.retime()
 	if d.p.TAR.Load() != TAR(c.a)&0x3ff {
 		d.SetAddr(c.a)
 	}
@@ -86,19 +86,24 @@ type Master struct {
 	dma dma.Channel
 	dcf dma.Config
 	din int
+
+	baud       int
+	clkChanged atomic.Bool
 }
 
 // NewMaster returns a new master-mode driver for p. If valid DMA channel is
 // given, the DMA will be used for bigger data transfers.
 func NewMaster(p *Periph, dc dma.Channel) *Master {
 	req := dma.I2C0_TX + dma.Config(num(p))*(dma.I2C1_TX-dma.I2C0_TX)
-	return &Master{
+	d := &Master{
 		name: "I2C" + string(rune('0'+num(p))),
 		p:    p,
 		dma:  dc,
 		dcf:  dma.En | dma.S8b | req,
 		din:  int(system.NextCPU()),
 	}
+	clock.AddNotifier(d)
+	return d
 }
 
 // Periph returns the underlying SPI peripheral.
@@ -107,7 +112,9 @@ func (d *Master) Periph() *Periph {
 }
 
 // Setup resets and configures the underlying I2C pripheral to operate in the
-// master mode with the given speed.
+// master mode with the given speed. If the PERI clock frequency changes (see
+// clock.SetSys) the SCL timing is recalculated automatically before opening
+// the next connection.
 func (d *Master) Setup(baudrate int) {
 	p := d.p
 	p.SetReset(true)
@@ -118,8 +125,34 @@ func (d *Master) Setup(baudrate int) {
 	p.CON.Store(MASTER_MODE | SLAVE_DISABLE | RESTART_EN | TX_EMPTY_CTRL | RX_FIFO_FULL_HLD_CTRL | FAST)
 	p.DMA_CR.Store(TDMAE | RDMAE) // enable by defalut, on/off at the DMA side
 
-	// Baudrate (calculations taken from PICO-SDK)
+	d.baud = baudrate
+	d.clkChanged.Store(false)
+	setBaudrate(p, baudrate)
+}
+
+// ClockChanged implements the clock.Notifier interface. It only records the
+// change, the SCL timing is recalculated when the next connection is open.
+func (d *Master) ClockChanged(clk clock.Clock) {
+	if clk == clock.PERI && d.baud > 0 {
+		d.clkChanged.Store(true)
+	}
+}
+
+// retime recalculates the SCL timing if the PERI clock has changed. It must be
+// called with d locked.
+func (d *Master) retime() {
+	if !d.clkChanged.Swap(false) {
+		return
+	}
+	p := d.p
+	en := p.ENABLE.Load()
+	p.ENABLE.Store(0) // the timing registers can be written only if disabled
+	setBaudrate(p, d.baud)
+	p.ENABLE.Store(en)
+}
 
+// setBaudrate configures the SCL timing (calculations taken from PICO-SDK).
+func setBaudrate(p *Periph, baudrate int) {
 	clk := clock.PERI.Freq()
 	cn := uint32((clk + int64(baudrate/2)) / int64(baudrate))
 
@@ -0,0 +1,32 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package internal
+
+import (
+	"embedded/mmio"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// Offsets of the ACCESSCTRL registers that control the access to peripherals.
+const (
+	AccSHA256   = 0x0b8
+	AccWATCHDOG = 0x0d8
+)
+
+// ACCESSCTRL permission bits.
+const (
+	AccSU  = 1 << 2 // Secure, Unprivileged (requires SP)
+	AccSP  = 1 << 3 // Secure, Privileged
+	AccDMA = 1 << 6 // the DMA can access the peripheral
+)
+
+// AccessGrant sets the perm bits in the ACCESSCTRL register at the offset reg.
+// It must be called in the privileged mode.
+func AccessGrant(reg uintptr, perm uint32) {
+	r := (*mmio.U32)(unsafe.Pointer(mmap.ACCESSCTRL_BASE + reg))
+	r.Store(0xacce<<16 | r.Load()&0xff | perm)
+}
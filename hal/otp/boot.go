@@ -0,0 +1,177 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otp
+
+// Rows of the predefined OTP data layout.
+const (
+	RowCHIPID0     = 0x000 // 4 ECC rows, the public device ID
+	RowRANDID0     = 0x004 // 8 ECC rows, per-device random ID
+	RowCRIT1       = 0x040 // 8 redundant raw rows
+	RowBOOT_FLAGS0 = 0x048 // 3 redundant raw rows
+	RowBOOT_FLAGS1 = 0x04b // 3 redundant raw rows
+	RowBOOTKEY0_0  = 0x080 // 4 boot keys, RowsPerBootKey ECC rows each
+
+	RowsPerBootKey = 16
+	NumBootKeys    = 4
+)
+
+const (
+	critCopies      = 8
+	critVote        = 3 // the bit is set if set in at least 3 of 8 rows
+	bootFlagsCopies = 3
+	bootFlagsVote   = 2
+)
+
+// ChipID returns the 64-bit public device ID.
+func ChipID() (id uint64, err error) {
+	var v [4]uint16
+	if err = ReadECC(RowCHIPID0, v[:]); err != nil {
+		return
+	}
+	for i := len(v) - 1; i >= 0; i-- {
+		id = id<<16 | uint64(v[i])
+	}
+	return
+}
+
+// RandID returns the 128-bit per-device random ID.
+func RandID() (id [16]byte, err error) {
+	var v [8]uint16
+	if err = ReadECC(RowRANDID0, v[:]); err != nil {
+		return
+	}
+	for i, w := range v {
+		id[2*i] = byte(w)
+		id[2*i+1] = byte(w >> 8)
+	}
+	return
+}
+
+// readVote reads n redundant raw rows starting from row and returns the value
+// in which a bit is set if it's set in at least vote rows.
+func readVote(row, n, vote int) (uint32, error) {
+	var buf [critCopies]uint32
+	rows := buf[:n]
+	if err := ReadRaw(row, rows); err != nil {
+		return 0, err
+	}
+	var v uint32
+	for bit := uint(0); bit < 24; bit++ {
+		cnt := 0
+		for _, r := range rows {
+			cnt += int(r >> bit & 1)
+		}
+		if cnt >= vote {
+			v |= 1 << bit
+		}
+	}
+	return v, nil
+}
+
+// writeCopies programs all n redundant raw rows starting from row with v.
+func writeCopies(row, n int, v uint32) error {
+	var buf [critCopies]uint32
+	rows := buf[:n]
+	for i := range rows {
+		rows[i] = v
+	}
+	return WriteRaw(row, rows)
+}
+
+// Crit1 represents the content of the CRIT1 rows.
+type Crit1 uint32
+
+const (
+	SecureBootEnable     Crit1 = 1 << 0 // enable the boot signature enforcement
+	SecureDebugDisable   Crit1 = 1 << 1 // disable the Secure debug access
+	DebugDisable         Crit1 = 1 << 2 // disable all debug access
+	BootArchRISCV        Crit1 = 1 << 3 // boot the RISC-V cores by default
+	GlitchDetectorEnable Crit1 = 1 << 4 // arm the glitch detectors at boot
+	GlitchDetectorSens   Crit1 = 3 << 5 // glitch detector sensitivity
+)
+
+// ReadCrit1 returns the CRIT1 value as seen by the boot ROM.
+func ReadCrit1() (Crit1, error) {
+	v, err := readVote(RowCRIT1, critCopies, critVote)
+	return Crit1(v), err
+}
+
+// SetCrit1 permanently sets the given CRIT1 bits. Be careful, for example
+// setting SecureBootEnable without a valid boot key makes the device unable to
+// boot any image.
+func SetCrit1(bits Crit1) error {
+	return writeCopies(RowCRIT1, critCopies, uint32(bits))
+}
+
+// BootFlags0 represents the content of the BOOT_FLAGS0 rows. See the RP2350
+// datasheet for the meaning of the individual bits.
+type BootFlags0 uint32
+
+// ReadBootFlags0 returns the BOOT_FLAGS0 value as seen by the boot ROM.
+func ReadBootFlags0() (BootFlags0, error) {
+	v, err := readVote(RowBOOT_FLAGS0, bootFlagsCopies, bootFlagsVote)
+	return BootFlags0(v), err
+}
+
+// SetBootFlags0 permanently sets the given BOOT_FLAGS0 bits.
+func SetBootFlags0(bits BootFlags0) error {
+	return writeCopies(RowBOOT_FLAGS0, bootFlagsCopies, uint32(bits))
+}
+
+// BootFlags1 represents the content of the BOOT_FLAGS1 rows.
+type BootFlags1 uint32
+
+const (
+	KeyValid       BootFlags1 = 0xf << 0  // mark BOOTKEYn as valid (bit n)
+	KeyInvalid     BootFlags1 = 0xf << 8  // mark BOOTKEYn as invalid (bit n+8)
+	DoubleTapDelay BootFlags1 = 0x7 << 16 // double tap timing (50 ms units)
+	DoubleTap      BootFlags1 = 0x1 << 19 // enter BOOTSEL on double RUN tap
+)
+
+// ReadBootFlags1 returns the BOOT_FLAGS1 value as seen by the boot ROM.
+func ReadBootFlags1() (BootFlags1, error) {
+	v, err := readVote(RowBOOT_FLAGS1, bootFlagsCopies, bootFlagsVote)
+	return BootFlags1(v), err
+}
+
+// SetBootFlags1 permanently sets the given BOOT_FLAGS1 bits.
+func SetBootFlags1(bits BootFlags1) error {
+	return writeCopies(RowBOOT_FLAGS1, bootFlagsCopies, uint32(bits))
+}
+
+// BootKey returns the content of the 16 ECC rows of the BOOTKEYn slot: the
+// SHA-256 hash of the public key (see picobin.BootKeyRows).
+func BootKey(n int) (rows [RowsPerBootKey]uint16, err error) {
+	if uint(n) >= NumBootKeys {
+		return rows, ErrBadRow
+	}
+	err = ReadECC(RowBOOTKEY0_0+n*RowsPerBootKey, rows[:])
+	return
+}
+
+// WriteBootKey permanently programs the BOOTKEYn slot with rows. It doesn't
+// mark the key as valid (see KeyValid).
+func WriteBootKey(n int, rows *[RowsPerBootKey]uint16) error {
+	if uint(n) >= NumBootKeys {
+		return ErrBadRow
+	}
+	return WriteECC(RowBOOTKEY0_0+n*RowsPerBootKey, rows[:])
+}
+
+// SecureBoot reports whether the boot ROM enforces the image signatures. It
+// also returns the set of the boot keys accepted by the boot ROM (bit n
+// corresponds to BOOTKEYn): marked valid and not marked invalid.
+func SecureBoot() (enabled bool, keys uint8, err error) {
+	crit1, err := ReadCrit1()
+	if err != nil {
+		return
+	}
+	flags1, err := ReadBootFlags1()
+	if err != nil {
+		return
+	}
+	keys = uint8(flags1&KeyValid) &^ uint8(flags1&KeyInvalid>>8)
+	return crit1&SecureBootEnable != 0, keys, nil
+}
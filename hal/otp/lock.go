@@ -0,0 +1,78 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otp
+
+import "strconv"
+
+// Lock represents the access permissions of an OTP page.
+type Lock uint8
+
+const (
+	ReadWrite    Lock = 0
+	ReadOnly     Lock = 1
+	Inaccessible Lock = 3
+)
+
+func (l Lock) String() string {
+	switch l {
+	case ReadWrite:
+		return "read-write"
+	case ReadOnly:
+		return "read-only"
+	case Inaccessible:
+		return "inaccessible"
+	}
+	return "Lock(" + strconv.Itoa(int(l)) + ")"
+}
+
+// SoftLock returns the current lock state of page for the Secure (s) and
+// Non-secure (ns) accesses. The state is initialized from the lock rows of the
+// page at reset and can be advanced by SetSoftLock.
+func SoftLock(page int) (s, ns Lock) {
+	v := OTP().SW_LOCK[page].Load()
+	return Lock(v & 3), Lock(v >> 2 & 3)
+}
+
+// SetSoftLock advances the lock state of page until the next reset. The lock
+// state can be only made more restrictive this way.
+func SetSoftLock(page int, s, ns Lock) {
+	OTP().SW_LOCK[page].Store(uint32(s&3) | uint32(ns&3)<<2)
+}
+
+// Lock rows: PAGEn_LOCK0 = lockRows+2*n, PAGEn_LOCK1 = lockRows+2*n+1.
+const lockRows = 0xf80
+
+// vote3 returns the bitwise majority of the three bytes of the triple
+// redundant raw row value v.
+func vote3(v uint32) uint8 {
+	a, b, c := uint8(v), uint8(v>>8), uint8(v>>16)
+	return a&b | a&c | b&c
+}
+
+// PageLock returns the permanent lock configuration of page stored in its
+// PAGEn_LOCK1 row: the permissions for the Secure (s), Non-secure (ns) and
+// the boot loader (bl) accesses.
+func PageLock(page int) (s, ns, bl Lock, err error) {
+	if uint(page) >= NumPages {
+		return 0, 0, 0, ErrBadRow
+	}
+	var v [1]uint32
+	if err = ReadRaw(lockRows+2*page+1, v[:]); err != nil {
+		return
+	}
+	b := vote3(v[0])
+	return Lock(b & 3), Lock(b >> 2 & 3), Lock(b >> 4 & 3), nil
+}
+
+// LockPage permanently sets the lock configuration of page (see PageLock).
+// The locks can be only made more restrictive. The new configuration takes
+// effect after reset (use SetSoftLock to apply it immediately).
+func LockPage(page int, s, ns, bl Lock) error {
+	if uint(page) >= NumPages {
+		return ErrBadRow
+	}
+	b := uint32(s&3) | uint32(ns&3)<<2 | uint32(bl&3)<<4
+	return WriteRaw(lockRows+2*page+1, []uint32{b | b<<8 | b<<16})
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package otp provides access to the one-time programmable memory.
+//
+// The OTP consists of 4096 rows, 24 bits each, divided into 64 pages of 64
+// rows. A row can store 16 bits of data protected by an ECC code or 24 raw
+// bits. The rows are read using the memory mapped read windows and programmed
+// using the boot ROM.
+//
+// Programming the OTP is irreversible so all writing functions return
+// ErrWriteLocked until UnlockWrites is called.
+package otp
+
+import (
+	"embedded/mmio"
+	"errors"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/bootrom"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+const (
+	NumRows     = 4096
+	RowsPerPage = 64
+	NumPages    = NumRows / RowsPerPage
+)
+
+var (
+	ErrBadRow      = errors.New("otp: bad row number")
+	ErrNoAccess    = errors.New("otp: page inaccessible")
+	ErrWriteLocked = errors.New("otp: writes locked")
+	ErrVerify      = errors.New("otp: verification failed")
+)
+
+var writeUnlocked atomic.Bool
+
+// UnlockWrites enables the functions that program the OTP.
+func UnlockWrites() {
+	writeUnlocked.Store(true)
+}
+
+// LockWrites disables the functions that program the OTP.
+func LockWrites() {
+	writeUnlocked.Store(false)
+}
+
+func checkRows(row, n int) error {
+	if row < 0 || n < 0 || row+n > NumRows {
+		return ErrBadRow
+	}
+	// Reading an inaccessible page causes the bus fault.
+	for pg := row / RowsPerPage; pg*RowsPerPage < row+n; pg++ {
+		if s, _ := SoftLock(pg); s == Inaccessible {
+			return ErrNoAccess
+		}
+	}
+	return nil
+}
+
+func eccWord(row int) *mmio.U32 {
+	return (*mmio.U32)(unsafe.Pointer(mmap.OTP_DATA_BASE + uintptr(row&^1)*2))
+}
+
+func rawWord(row int) *mmio.U32 {
+	return (*mmio.U32)(unsafe.Pointer(mmap.OTP_DATA_RAW_BASE + uintptr(row)*4))
+}
+
+// ReadECC reads len(p) rows starting from row using the ECC read window. The
+// single-bit errors are corrected by hardware.
+func ReadECC(row int, p []uint16) error {
+	if err := checkRows(row, len(p)); err != nil {
+		return err
+	}
+	for i := range p {
+		r := row + i
+		p[i] = uint16(eccWord(r).Load() >> (uint(r&1) * 16))
+	}
+	return nil
+}
+
+// ReadRaw reads len(p) raw 24-bit rows starting from row.
+func ReadRaw(row int, p []uint32) error {
+	if err := checkRows(row, len(p)); err != nil {
+		return err
+	}
+	for i := range p {
+		p[i] = rawWord(row+i).Load() & 0xffffff
+	}
+	return nil
+}
+
+// WriteECC programs len(p) rows starting from row with the ECC protected
+// data from p and verifies the result. A row that contains ECC data can't be
+// reprogrammed with a different value.
+func WriteECC(row int, p []uint16) error {
+	if !writeUnlocked.Load() {
+		return ErrWriteLocked
+	}
+	if err := checkRows(row, len(p)); err != nil {
+		return err
+	}
+	buf := make([]byte, len(p)*2)
+	for i, v := range p {
+		buf[2*i] = byte(v)
+		buf[2*i+1] = byte(v >> 8)
+	}
+	if err := bootrom.OTPAccess(buf, row, true, true); err != nil {
+		return err
+	}
+	for i, v := range p {
+		r := row + i
+		if uint16(eccWord(r).Load()>>(uint(r&1)*16)) != v {
+			return ErrVerify
+		}
+	}
+	return nil
+}
+
+// WriteRaw programs len(p) raw rows starting from row and verifies the
+// result. Only the 24 least significant bits of every p element are used.
+// Programming can only set bits so the rows can be programmed many times, but
+// the cleared bits in p don't clear the bits already set in OTP.
+func WriteRaw(row int, p []uint32) error {
+	if !writeUnlocked.Load() {
+		return ErrWriteLocked
+	}
+	if err := checkRows(row, len(p)); err != nil {
+		return err
+	}
+	buf := make([]byte, len(p)*4)
+	for i, v := range p {
+		buf[4*i] = byte(v)
+		buf[4*i+1] = byte(v >> 8)
+		buf[4*i+2] = byte(v >> 16)
+	}
+	if err := bootrom.OTPAccess(buf, row, true, false); err != nil {
+		return err
+	}
+	for i, v := range p {
+		v &= 0xffffff
+		if rawWord(row+i).Load()&v != v {
+			return ErrVerify
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otp
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// Periph represents the part of the OTP controller used by this package.
+type Periph struct {
+	_ structs.HostLayout
+
+	SW_LOCK [NumPages]mmio.U32
+}
+
+// OTP returns the OTP controller.
+func OTP() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.OTP_BASE))
+}
@@ -0,0 +1,12 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// func halt()
+TEXT ·halt(SB),NOSPLIT|NOFRAME,$0-0
+	CPSID
+loop:
+	WFI
+	B  loop
@@ -0,0 +1,104 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package power
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"runtime"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/p/clocks"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/pll"
+	"github.com/embeddedgo/pico/p/xosc"
+)
+
+// Oscillator to run from in the dormant mode.
+type Osc uint8
+
+const (
+	XOSC Osc = iota // crystal oscillator
+	ROSC            // ring oscillator
+)
+
+const dormantVal = 0x636f6d61 // "coma"
+
+type roscPeriph struct {
+	_       structs.HostLayout
+	CTRL    mmio.U32
+	FREQA   mmio.U32
+	FREQB   mmio.U32
+	RANDOM  mmio.U32
+	DORMANT mmio.U32
+	DIV     mmio.U32
+	PHASE   mmio.U32
+	STATUS  mmio.U32
+}
+
+const roscStable = 1 << 31
+
+func rosc() *roscPeriph {
+	return (*roscPeriph)(unsafe.Pointer(mmap.ROSC_BASE))
+}
+
+// Dormant switches the system clocks to osc, stops the PLLs and puts the osc
+// into the dormant state which stops all clocks. Dormant returns after the
+// wakeup with the clocks restored by system.RestoreClocks.
+//
+// Configure the wakeup source before calling Dormant. It can be a GPIO event
+// (see iomux.Pin.SetDstIRQ with iomux.DormantWake destination) or an alarm of
+// the always-on timer (see aon.SetAlarm) that must run from LPOSC.
+//
+// The system timer is stopped in the dormant mode so the time measured by
+// the time package doesn't advance. Use aon.SyncSystemTime to correct the wall
+// time after the wakeup.
+func Dormant(osc Osc) {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+
+	// Run REF from osc and SYS from REF.
+	clk := clocks.CLOCKS()
+	ref := &clk.CLK[clocks.REF]
+	src := clocks.REF_XOSC_CLKSRC
+	if osc == ROSC {
+		src = clocks.REF_ROSC_CLKSRC_PH
+	}
+	ref.CTRL.StoreBits(clocks.REF_SRC, src)
+	for ref.SELECTED.Load() != 1<<uint(src>>clocks.REF_SRCn) {
+	}
+	sys := &clk.CLK[clocks.SYS]
+	internal.AtomicClear(&sys.CTRL, clocks.SYS_SRC)
+	for sys.SELECTED.LoadBits(0b11) != 0b01 {
+	}
+
+	// Stop the clocks driven by PLL_USB and power down both PLLs.
+	internal.AtomicClear(&clk.CLK[clocks.USB].CTRL, clocks.USB_ENABLE)
+	internal.AtomicClear(&clk.CLK[clocks.ADC].CTRL, clocks.ADC_ENABLE)
+	pdAll := pll.PD | pll.DSMPD | pll.POSTDIVPD | pll.VCOPD
+	pll.SYS().PWR.Store(pdAll)
+	pll.USB().PWR.Store(pdAll)
+
+	// Go dormant and wait for the oscillator after the wakeup.
+	if osc == XOSC {
+		x := xosc.XOSC()
+		x.DORMANT.Store(dormantVal)
+		for x.STATUS.LoadBits(xosc.STABLE) == 0 {
+		}
+	} else {
+		r := rosc()
+		r.DORMANT.Store(dormantVal)
+		for r.STATUS.Load()&roscStable == 0 {
+		}
+	}
+
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+
+	system.RestoreClocks()
+}
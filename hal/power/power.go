@@ -0,0 +1,46 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package power provides the low power modes of the RP2350.
+//
+// In the sleep mode the CPUs are stopped when idle and the clocks not selected
+// by SetSleepClocks are gated. Any enabled interrupt (including the system
+// timer alarm) wakes the system up. The code doesn't need to be aware of the
+// sleep mode, it's simply entered when both CPUs are idle.
+//
+// In the dormant mode (see Dormant) the oscillators are stopped so all clocks
+// derived from them are stopped too. The system can be woken up by a GPIO
+// event or by the always-on timer alarm.
+//
+// The lowest power consumption can be achieved by powering off the switched
+// core power domain (see PowerOff). The chip is reset when woken up, but the
+// state of the always-on timer and the POWMAN scratch registers is preserved.
+package power
+
+import (
+	"embedded/rtos"
+	"runtime"
+
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/p/clocks"
+)
+
+// SetSleepClocks sets the clocks that remain enabled in the sleep state. Keep
+// at least the clocks used by the system timer (e.g. clocks.SYS_SIO_EN and
+// clocks.REF_TICKS_EN in case of the default RISCV platform timer) and by the
+// peripherals that should wake the system up.
+func SetSleepClocks(en0 clocks.CLK0_EN, en1 clocks.CLK_EN1) {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := clocks.CLOCKS()
+	c.SLEEP_EN0.Store(en0)
+	c.SLEEP_EN1.Store(clocks.CLK1_EN(en1))
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// EnableSleep enables or disables the sleep mode.
+func EnableSleep(enable bool) {
+	system.SetDeepSleep(enable)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package power
+
+import (
+	"embedded/rtos"
+	"errors"
+	"runtime"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/powman"
+	"github.com/embeddedgo/pico/hal/system"
+)
+
+var (
+	ErrStateIgnored = errors.New("power: state request ignored (pending wakeup)")
+	ErrBadState     = errors.New("power: bad state request")
+)
+
+// Power domains that can remain powered when the switched core domain is
+// powered off.
+type Domain uint8
+
+const (
+	SRAM1 Domain = 1 << 0 // SRAM banks 4-9 (0x20040000-0x2007FFFF)
+	SRAM0 Domain = 1 << 1 // SRAM banks 0-3 (0x20000000-0x2003FFFF)
+	XIP   Domain = 1 << 2 // XIP cache
+)
+
+// Wakeup sources reported by LastWakeup.
+type Wakeup uint8
+
+const (
+	WakeupReset Wakeup = 1 << 0 // chip reset (see watchdog.Reason)
+	WakeupPin0  Wakeup = 1 << 1 // wakeup pin 0 (see SetWakeupPin)
+	WakeupPin1  Wakeup = 1 << 2 // wakeup pin 1
+	WakeupPin2  Wakeup = 1 << 3 // wakeup pin 2
+	WakeupPin3  Wakeup = 1 << 4 // wakeup pin 3
+	WakeupDebug Wakeup = 1 << 5 // debugger
+	WakeupAlarm Wakeup = 1 << 6 // always-on timer alarm (see aon.SetAlarm)
+)
+
+// LastWakeup returns the source that triggered the last power up of the
+// switched core domain.
+func LastWakeup() Wakeup {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	w := powman.POWMAN().LAST_SWCORE_PWRUP.Load()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return Wakeup(w)
+}
+
+// SetWakeupPin configures the n-th (0-3) POWMAN wakeup source to power up the
+// chip when the pin is high (high == true) or low. If edge is true the wakeup
+// is triggered by the rising (high == true) or falling edge. SetWakeupPin
+// enables the pin input but doesn't change other pad settings (e.g. pull-up).
+func SetWakeupPin(n int, pin iomux.Pin, edge, high bool) {
+	cfg := powman.PWRUP(pin) & powman.SOURCE
+	if edge {
+		cfg |= powman.EDGE
+	}
+	if high {
+		cfg |= powman.HIGH_RISING
+	}
+	pin.Setup(pin.Config()&^iomux.ISO | iomux.InpEn)
+	r := &powman.POWMAN().PWRUP[n]
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r.Store(powman.PASSWD | cfg) // disabling clears a pending event
+	r.Store(powman.PASSWD | cfg | powman.STATUS)
+	r.Store(powman.PASSWD | cfg | powman.ENABLE)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// ClearWakeupPin disables the n-th POWMAN wakeup source.
+func ClearWakeupPin(n int) {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	powman.POWMAN().PWRUP[n].Store(powman.PASSWD)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// PowerOff powers off the switched core domain (the CPUs and all peripherals
+// except POWMAN). The domains specified by retain remain powered, so the
+// content of the selected SRAM banks is preserved. PowerOff returns only in
+// case of error.
+//
+// The chip is reset when woken up by one of the wakeup pins (see SetWakeupPin)
+// or by the always-on timer alarm (see aon.SetAlarm), so the program starts
+// from the beginning. Use LastWakeup to check the wakeup source and the POWMAN
+// scratch registers to keep a small amount of state.
+func PowerOff(retain Domain) error {
+	p := powman.POWMAN()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+
+	// Power up all SRAM banks on the wakeup.
+	internal.AtomicClear(&p.SEQ_CFG, powman.PASSWD|powman.HW_PWRUP_SRAM0|powman.HW_PWRUP_SRAM1)
+
+	// Boot normally after the wakeup.
+	for i := range p.BOOT {
+		p.BOOT[i].Store(0)
+	}
+
+	// In the STATE register a set bit means the domain is powered down.
+	state := powman.STATE(^retain&(SRAM0|SRAM1|XIP)) | 1<<3 // SWCORE
+	internal.AtomicClear(&p.STATE, powman.PASSWD|powman.REQ_IGNORED)
+	p.STATE.Store(powman.PASSWD | state<<powman.REQn)
+	s := p.STATE.Load()
+	var err error
+	switch {
+	case s&powman.REQ_IGNORED != 0:
+		err = ErrStateIgnored
+	case s&(powman.BAD_SW_REQ|powman.BAD_HW_REQ) != 0:
+		err = ErrBadState
+	}
+	if err != nil {
+		rtos.SetPrivLevel(pl)
+		runtime.UnlockOSThread()
+		return err
+	}
+
+	// The power down starts when both CPUs are halted.
+	system.SetDeepSleep(true)
+	halt()
+	return nil
+}
+
+// halt disables interrupts and waits for the power down.
+func halt()
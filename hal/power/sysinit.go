@@ -0,0 +1,7 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package power
+
+import _ "github.com/embeddedgo/pico/hal/system/init"
@@ -0,0 +1,191 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package powman
+
+type VREG_CTRL uint32
+
+const (
+	HT_TH                 VREG_CTRL = 0x07 << 4  //+ High temperature protection threshold (100, 105, 110, 115, 120, 125, 135, 150 °C).
+	DISABLE_VOLTAGE_LIMIT VREG_CTRL = 0x01 << 8  //+ Allow VSEL to select voltages above 1.30 V.
+	ISOLATE               VREG_CTRL = 0x01 << 12 //+ Isolates the VREG control interface.
+	UNLOCK                VREG_CTRL = 0x01 << 13 //+ Unlocks the VREG control interface. It cannot be relocked.
+	RST_N                 VREG_CTRL = 0x01 << 15 //+ 0 returns the regulator to its startup settings.
+)
+
+const (
+	HT_THn                 = 4
+	DISABLE_VOLTAGE_LIMITn = 8
+	ISOLATEn               = 12
+	UNLOCKn                = 13
+	RST_Nn                 = 15
+)
+
+type VREG_STS uint32
+
+const (
+	STARTUP VREG_STS = 0x01 << 0 //+ Regulator is starting up.
+	VOUT_OK VREG_STS = 0x01 << 4 //+ Output is in regulation.
+)
+
+const (
+	STARTUPn = 0
+	VOUT_OKn = 4
+)
+
+type VREG uint32
+
+const (
+	HIZ                VREG = 0x01 << 1  //+ High impedance mode.
+	VSEL               VREG = 0x1F << 4  //+ Output voltage select (see SetVoltage).
+	UPDATE_IN_PROGRESS VREG = 0x01 << 15 //+ Regulator state is being updated, writes are ignored.
+)
+
+const (
+	HIZn                = 1
+	VSELn               = 4
+	UPDATE_IN_PROGRESSn = 15
+)
+
+type SEQ_CFG uint32
+
+const (
+	HW_PWRUP_SRAM1   SEQ_CFG = 0x01 << 0  //+ SRAM1 state when powering up swcore from a low power state. 0: power-up, 1: no change.
+	HW_PWRUP_SRAM0   SEQ_CFG = 0x01 << 1  //+ SRAM0 state when powering up swcore from a low power state. 0: power-up, 1: no change.
+	USE_VREG_LP      SEQ_CFG = 0x01 << 4  //+ Switch VREG to low power mode when swcore is powered down.
+	USE_VREG_HP      SEQ_CFG = 0x01 << 5  //+ Switch VREG to high power mode when swcore is powered up.
+	USE_BOD_LP       SEQ_CFG = 0x01 << 6  //+ Switch BOD to low power mode when swcore is powered down.
+	USE_BOD_HP       SEQ_CFG = 0x01 << 7  //+ Switch BOD to high power mode when swcore is powered up.
+	RUN_LPOSC_IN_LP  SEQ_CFG = 0x01 << 8  //+ Keep LPOSC running when swcore is powered down.
+	USE_FAST_POWCK   SEQ_CFG = 0x01 << 12 //+ Clock POWMAN from clk_ref when swcore is powered.
+	USING_VREG_LP    SEQ_CFG = 0x01 << 16 //+ VREG is in low power mode.
+	USING_BOD_LP     SEQ_CFG = 0x01 << 17 //+ BOD is in low power mode.
+	USING_FAST_POWCK SEQ_CFG = 0x01 << 20 //+ POWMAN is clocked from clk_ref.
+)
+
+const (
+	HW_PWRUP_SRAM1n   = 0
+	HW_PWRUP_SRAM0n   = 1
+	USE_VREG_LPn      = 4
+	USE_VREG_HPn      = 5
+	USE_BOD_LPn       = 6
+	USE_BOD_HPn       = 7
+	RUN_LPOSC_IN_LPn  = 8
+	USE_FAST_POWCKn   = 12
+	USING_VREG_LPn    = 16
+	USING_BOD_LPn     = 17
+	USING_FAST_POWCKn = 20
+)
+
+type STATE uint32
+
+const (
+	CURRENT             STATE = 0x0F << 0  //+ Current power state. Bit 3: SWCORE, 2: XIP, 1: SRAM0, 0: SRAM1; 1 means powered down.
+	REQ                 STATE = 0x0F << 4  //+ Requested power state (coded as CURRENT).
+	REQ_IGNORED         STATE = 0x01 << 8  //+ Request ignored because of a pending pwrup request.
+	PWRUP_WHILE_WAITING STATE = 0x01 << 9  //+ Request ignored because of a pending pwrup request.
+	BAD_SW_REQ          STATE = 0x01 << 10 //+ Bad software initiated state request. No action taken.
+	BAD_HW_REQ          STATE = 0x01 << 11 //+ Bad hardware initiated state request. Went back to state 0.
+	WAITING             STATE = 0x01 << 12 //+ Waiting for the processors to halt.
+	CHANGING            STATE = 0x01 << 13 //+ Power state transition in progress.
+)
+
+const (
+	CURRENTn             = 0
+	REQn                 = 4
+	REQ_IGNOREDn         = 8
+	PWRUP_WHILE_WAITINGn = 9
+	BAD_SW_REQn          = 10
+	BAD_HW_REQn          = 11
+	WAITINGn             = 12
+	CHANGINGn            = 13
+)
+
+type PWRUP uint32
+
+const (
+	SOURCE      PWRUP = 0x3F << 0  //+ GPIO number (0-47) or QSPI pin (48-53).
+	ENABLE      PWRUP = 0x01 << 6  //+ Enable the wakeup source. Clearing it clears a pending wakeup event.
+	DIRECTION   PWRUP = 0x01 << 7  //+
+	LOW_FALLING PWRUP = 0x00 << 7  //  Wake up on low level or falling edge.
+	HIGH_RISING PWRUP = 0x01 << 7  //  Wake up on high level or rising edge.
+	MODE        PWRUP = 0x01 << 8  //+
+	LEVEL       PWRUP = 0x00 << 8  //  Level detect.
+	EDGE        PWRUP = 0x01 << 8  //  Edge detect.
+	STATUS      PWRUP = 0x01 << 9  //+ Status of the wakeup. Write 1 to clear a latched edge.
+	RAW_STATUS  PWRUP = 0x01 << 10 //+ Value of the selected pin (only if ENABLE is set).
+)
+
+const (
+	SOURCEn     = 0
+	ENABLEn     = 6
+	DIRECTIONn  = 7
+	MODEn       = 8
+	STATUSn     = 9
+	RAW_STATUSn = 10
+)
+
+type EXT_TIME_REF uint32
+
+const (
+	SOURCE_SEL EXT_TIME_REF = 0x03 << 0 //+ 0: GPIO12, 1: GPIO20, 2: GPIO14, 3: GPIO22.
+	DRIVE_LPCK EXT_TIME_REF = 0x01 << 4 //+ Use the selected GPIO to drive the 32 kHz low power clock instead of LPOSC.
+)
+
+const (
+	SOURCE_SELn = 0
+	DRIVE_LPCKn = 4
+)
+
+type TIMER uint32
+
+const (
+	NONSEC_WRITE    TIMER = 0x01 << 0  //+ Non-secure software can write to the timer registers.
+	RUN             TIMER = 0x01 << 1  //+ Timer enable.
+	CLEAR           TIMER = 0x01 << 2  //+ Clears the timer, does not disable it and does not affect the alarm.
+	ALARM_ENAB      TIMER = 0x01 << 4  //+ Enables the alarm. Must be disabled while writing the alarm time.
+	PWRUP_ON_ALARM  TIMER = 0x01 << 5  //+ Alarm wakes the chip from low power mode.
+	ALARM           TIMER = 0x01 << 6  //+ Alarm has fired. Write 1 to clear.
+	USE_LPOSC       TIMER = 0x01 << 8  //+ Switch to LPOSC as the source of the 1 kHz tick.
+	USE_XOSC        TIMER = 0x01 << 9  //+ Switch to XOSC as the source of the 1 kHz tick.
+	USE_GPIO_1KHZ   TIMER = 0x01 << 10 //+ Switch to GPIO as the source of the 1 kHz tick.
+	USE_GPIO_1HZ    TIMER = 0x01 << 13 //+ Use the GPIO as the reference for the second counter.
+	USING_XOSC      TIMER = 0x01 << 16 //+ Timer is running from XOSC.
+	USING_LPOSC     TIMER = 0x01 << 17 //+ Timer is running from LPOSC.
+	USING_GPIO_1KHZ TIMER = 0x01 << 18 //+ Timer is running from a 1 kHz GPIO source.
+	USING_GPIO_1HZ  TIMER = 0x01 << 19 //+ Timer is synchronised to a 1 Hz GPIO source.
+)
+
+const (
+	NONSEC_WRITEn    = 0
+	RUNn             = 1
+	CLEARn           = 2
+	ALARM_ENABn      = 4
+	PWRUP_ON_ALARMn  = 5
+	ALARMn           = 6
+	USE_LPOSCn       = 8
+	USE_XOSCn        = 9
+	USE_GPIO_1KHZn   = 10
+	USE_GPIO_1HZn    = 13
+	USING_XOSCn      = 16
+	USING_LPOSCn     = 17
+	USING_GPIO_1KHZn = 18
+	USING_GPIO_1HZn  = 19
+)
+
+type INTR uint32
+
+const (
+	INT_VREG_OUTPUT_LOW     INTR = 0x01 << 0 //+
+	INT_TIMER               INTR = 0x01 << 1 //+
+	INT_STATE_REQ_IGNORED   INTR = 0x01 << 2 //+ Source is STATE.REQ_IGNORED.
+	INT_PWRUP_WHILE_WAITING INTR = 0x01 << 3 //+ Source is STATE.PWRUP_WHILE_WAITING.
+)
+
+const (
+	INT_VREG_OUTPUT_LOWn     = 0
+	INT_TIMERn               = 1
+	INT_STATE_REQ_IGNOREDn   = 2
+	INT_PWRUP_WHILE_WAITINGn = 3
+)
@@ -0,0 +1,74 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package powman provides access to the registers of the Power Manager
+// (POWMAN). The POWMAN is in the always-on power domain and controls the
+// voltage regulator, the brown-out detector, the low power oscillator, the
+// always-on timer and the power states of the other power domains.
+//
+// Every write to a POWMAN register must contain PASSWD in the upper 16 bits,
+// otherwise it is ignored and the BADPASSWD register is set. This applies also
+// to the atomic set/clear aliases (use internal.AtomicSet(r, PASSWD|mask)).
+package powman
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// PASSWD must be written to the upper 16 bits of every POWMAN register.
+const PASSWD = 0x5afe << 16
+
+type Periph struct {
+	_ structs.HostLayout
+
+	BADPASSWD           mmio.U32
+	VREG_CTRL           mmio.R32[VREG_CTRL]
+	VREG_STS            mmio.R32[VREG_STS]
+	VREG                mmio.R32[VREG]
+	VREG_LP_ENTRY       mmio.U32
+	VREG_LP_EXIT        mmio.U32
+	BOD_CTRL            mmio.U32
+	BOD                 mmio.U32
+	BOD_LP_ENTRY        mmio.U32
+	BOD_LP_EXIT         mmio.U32
+	LPOSC               mmio.U32
+	CHIP_RESET          mmio.U32
+	WDSEL               mmio.U32
+	SEQ_CFG             mmio.R32[SEQ_CFG]
+	STATE               mmio.R32[STATE]
+	POW_FASTDIV         mmio.U32
+	POW_DELAY           mmio.U32
+	EXT_CTRL            [2]mmio.U32
+	EXT_TIME_REF        mmio.R32[EXT_TIME_REF]
+	LPOSC_FREQ_KHZ_INT  mmio.U32
+	LPOSC_FREQ_KHZ_FRAC mmio.U32
+	XOSC_FREQ_KHZ_INT   mmio.U32
+	XOSC_FREQ_KHZ_FRAC  mmio.U32
+	SET_TIME            [4]mmio.U32 // 63to48, 47to32, 31to16, 15to0
+	READ_TIME_UPPER     mmio.U32
+	READ_TIME_LOWER     mmio.U32
+	ALARM_TIME          [4]mmio.U32 // 63to48, 47to32, 31to16, 15to0
+	TIMER               mmio.R32[TIMER]
+	PWRUP               [4]mmio.R32[PWRUP]
+	CURRENT_PWRUP_REQ   mmio.U32
+	LAST_SWCORE_PWRUP   mmio.U32
+	DBG_PWRCFG          mmio.U32
+	BOOTDIS             mmio.U32
+	DBGCONFIG           mmio.U32
+	SCRATCH             [8]mmio.U32
+	BOOT                [4]mmio.U32
+	INTR                mmio.R32[INTR]
+	INTE                mmio.R32[INTR]
+	INTF                mmio.R32[INTR]
+	INTS                mmio.R32[INTR]
+}
+
+// POWMAN returns the Power Manager peripheral.
+func POWMAN() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.POWMAN_BASE))
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package powman
+
+import "github.com/embeddedgo/pico/hal/internal"
+
+// vsel contains the regulator output voltages (mV) selected by VREG.VSEL.
+var vsel = [32]uint16{
+	550, 600, 650, 700, 750, 800, 850, 900,
+	950, 1000, 1050, 1100, 1150, 1200, 1250, 1300,
+	1350, 1400, 1500, 1600, 1650, 1700, 1800, 1900,
+	2000, 2350, 2500, 2650, 2800, 3000, 3150, 3300,
+}
+
+// MaxLimitedVoltage is the maximum core voltage (mV) that can be selected
+// without disabling the voltage limit.
+const MaxLimitedVoltage = 1300
+
+// Voltage returns the core voltage (mV) selected in the voltage regulator.
+func Voltage() int {
+	return int(vsel[POWMAN().VREG.LoadBits(VSEL)>>VSELn])
+}
+
+// SetVoltage sets the output voltage of the core voltage regulator to the
+// lowest available value not less than mv and waits for the regulation. It
+// returns the voltage set. The voltages above MaxLimitedVoltage are allowed
+// but can damage the chip. SetVoltage panics if mv is greater than 3300.
+func SetVoltage(mv int) int {
+	if mv > int(vsel[len(vsel)-1]) {
+		panic("powman: voltage too high")
+	}
+	var sel int
+	for int(vsel[sel]) < mv {
+		sel++
+	}
+	p := POWMAN()
+	internal.AtomicSet(&p.VREG_CTRL, PASSWD|UNLOCK)
+	if vsel[sel] > MaxLimitedVoltage {
+		internal.AtomicSet(&p.VREG_CTRL, PASSWD|DISABLE_VOLTAGE_LIMIT)
+	}
+	for p.VREG.LoadBits(UPDATE_IN_PROGRESS) != 0 {
+	}
+	v := p.VREG.Load()
+	p.VREG.Store(PASSWD | v&^VSEL | VREG(sel)<<VSELn)
+	for p.VREG.LoadBits(UPDATE_IN_PROGRESS) != 0 {
+	}
+	for p.VREG_STS.LoadBits(VOUT_OK) == 0 {
+	}
+	return int(vsel[sel])
+}
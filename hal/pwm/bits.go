@@ -0,0 +1,42 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pwm
+
+type CSR uint32
+
+const (
+	EN         CSR = 0x01 << 0 //+ Enable the PWM slice.
+	PH_CORRECT CSR = 0x01 << 1 //+ Enable phase-correct modulation.
+	A_INV      CSR = 0x01 << 2 //+ Invert output A.
+	B_INV      CSR = 0x01 << 3 //+ Invert output B.
+	DIVMODE    CSR = 0x03 << 4 //+
+	DIV        CSR = 0x00 << 4 //  Free-running counting at rate of fractional divider.
+	LEVEL      CSR = 0x01 << 4 //  Fractional divider is gated by the PWM B pin.
+	RISE       CSR = 0x02 << 4 //  Counter advances with rising edge of the B pin.
+	FALL       CSR = 0x03 << 4 //  Counter advances with falling edge of the B pin.
+	PH_RET     CSR = 0x01 << 6 //+ Retard the phase of the counter by 1 count.
+	PH_ADV     CSR = 0x01 << 7 //+ Advance the phase of the counter by 1 count.
+)
+
+const (
+	ENn         = 0
+	PH_CORRECTn = 1
+	A_INVn      = 2
+	B_INVn      = 3
+	DIVMODEn    = 4
+	PH_RETn     = 6
+	PH_ADVn     = 7
+)
+
+// DIV bits
+const (
+	FRAC uint32 = 0x0F << 0 //+ Fractional part of the clock divider.
+	INT  uint32 = 0xFF << 4 //+ Integer part of the clock divider.
+)
+
+const (
+	FRACn = 0
+	INTn  = 4
+)
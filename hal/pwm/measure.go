@@ -0,0 +1,98 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pwm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/iomux"
+	"github.com/embeddedgo/pico/hal/system/clock"
+)
+
+var (
+	ErrOverflow = errors.New("pwm: counter overflow")
+	ErrWindow   = errors.New("pwm: measurement window too long")
+)
+
+// UseInputPin is a helper function that can be used to configure an IO pin as
+// the PWM input. Only the pins connected to the B channels (odd GPIO numbers)
+// can be used as inputs. UseInputPin returns the slice that the pin is
+// connected to. It panics if the pin isn't connected to a B channel.
+func (p *Periph) UseInputPin(pin iomux.Pin) Slice {
+	c := p.PinChannel(pin)
+	if c.n != 1 {
+		panic("pwm: not a B pin")
+	}
+	pin.SetAltFunc(pin.AltFunc()&^iomux.Func | iomux.PWM)
+	pin.Setup(iomux.InpEn | iomux.OutDis)
+	return c.s
+}
+
+// count runs the slice s configured in the divmode mode with the clock divider
+// div16/16 for the window time. It returns the counter value and the actual
+// measurement time.
+func count(s Slice, divmode CSR, div16 int, window time.Duration) (cnt int, dt time.Duration, err error) {
+	r := s.Regs()
+	r.CSR.Store(divmode)
+	r.DIV.Store(uint32(div16))
+	r.TOP.Store(0xffff)
+	r.CTR.Store(0)
+	s.p.INTR.Store(uint32(s.Mask())) // clear the wrap flag
+	t0 := time.Now()
+	s.Enable()
+	time.Sleep(window)
+	s.Disable()
+	dt = time.Since(t0)
+	cnt = s.Counter()
+	if s.p.INTR.Load()&uint32(s.Mask()) != 0 {
+		err = ErrOverflow
+	}
+	return
+}
+
+// MeasureFreq measures the frequency of the signal on the pin by counting its
+// rising edges during the window time. The pin must be connected to a B
+// channel (see UseInputPin) and the slice it belongs to is used exclusively
+// during the measurement. The measured frequency must be lower than the half
+// of the system clock frequency and the number of edges counted during the
+// window must not exceed 65535 (ErrOverflow).
+func (p *Periph) MeasureFreq(pin iomux.Pin, window time.Duration) (hz int, err error) {
+	s := p.UseInputPin(pin)
+	cnt, dt, err := count(s, RISE, 1<<INTn, window)
+	if dt <= 0 {
+		return 0, err
+	}
+	return int((int64(cnt)*int64(time.Second) + int64(dt)/2) / int64(dt)), err
+}
+
+// MeasureDuty measures the duty cycle of the signal on the pin using the
+// level-gated mode of the slice connected to the pin. The pin must be connected
+// to a B channel (see UseInputPin) and the slice it belongs to is used
+// exclusively during the measurement. The window should cover many periods of
+// the measured signal and must not exceed 65536*256/clock.SYS.Freq() seconds
+// (ErrWindow). MeasureDuty returns the duty cycle as a number from 0 to 1.
+func (p *Periph) MeasureDuty(pin iomux.Pin, window time.Duration) (duty float32, err error) {
+	s := p.UseInputPin(pin)
+	// Select the smallest clock divider that does not allow the counter to
+	// overflow during the window time.
+	ticks16 := clock.SYS.Freq() << 4 * window.Microseconds() / 1e6
+	div16 := int((ticks16 + 0xffff - 1) / 0xffff)
+	if div16 < 1<<4 {
+		div16 = 1 << 4
+	} else if div16 > int(INT|FRAC) {
+		return 0, ErrWindow
+	}
+	cnt, dt, err := count(s, LEVEL, div16, window)
+	max := float32(clock.SYS.Freq()<<4) / float32(div16) * float32(dt) / float32(time.Second)
+	if max <= 0 {
+		return 0, err
+	}
+	duty = float32(cnt) / max
+	if duty > 1 {
+		duty = 1
+	}
+	return duty, err
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pwm
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/resets"
+)
+
+// SliceRegs represents the registers of a single PWM slice.
+type SliceRegs struct {
+	_ structs.HostLayout
+
+	CSR mmio.R32[CSR]
+	DIV mmio.U32
+	CTR mmio.U32
+	CC  mmio.U32
+	TOP mmio.U32
+}
+
+type IRQRegs struct {
+	_ structs.HostLayout
+
+	INTE mmio.U32
+	INTF mmio.U32
+	INTS mmio.U32
+}
+
+type Periph struct {
+	_ structs.HostLayout
+
+	CH   [12]SliceRegs
+	EN   mmio.U32
+	INTR mmio.U32
+	IRQ  [2]IRQRegs
+}
+
+// PWM returns the PWM peripheral.
+func PWM(n int) *Periph {
+	if n != 0 {
+		panic("wrong PWM number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.PWM_BASE))
+}
+
+// SetReset allows to assert/deassert the reset signal to the PWM peripheral.
+func (p *Periph) SetReset(assert bool) {
+	internal.SetReset(resets.PWM, assert)
+}
+
+// Enable enables the slices selected by the mask (bit n selects slice n) at
+// the same time, so they can run in perfect sync.
+func (p *Periph) Enable(mask uint16) {
+	internal.AtomicSetU32(&p.EN, uint32(mask))
+}
+
+// Disable disables the slices selected by the mask at the same time.
+func (p *Periph) Disable(mask uint16) {
+	internal.AtomicClearU32(&p.EN, uint32(mask))
+}
+
+// Enabled returns the bitmask of enabled slices.
+func (p *Periph) Enabled() uint16 {
+	return uint16(p.EN.Load())
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pwm
+
+import (
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/system/clock"
+)
+
+// Slice represents a PWM slice. Each slice contains a 16-bit counter and drives
+// two outputs (channels A and B).
+type Slice struct {
+	p *Periph
+	n int
+}
+
+// Slice returns the n-th slice of the PWM peripheral.
+func (p *Periph) Slice(n int) Slice {
+	if uint(n) >= uint(len(p.CH)) {
+		panic("wrong PWM slice number")
+	}
+	return Slice{p, n}
+}
+
+// Periph returns the PWM peripheral that the slice belongs to.
+func (s Slice) Periph() *Periph {
+	return s.p
+}
+
+// Num returns the slice number.
+func (s Slice) Num() int {
+	return s.n
+}
+
+// Mask returns the bitmask that selects the slice in the Periph.Enable and
+// Periph.Disable methods.
+func (s Slice) Mask() uint16 {
+	return 1 << uint(s.n)
+}
+
+// Regs returns the registers of the slice.
+func (s Slice) Regs() *SliceRegs {
+	return &s.p.CH[s.n]
+}
+
+// Setup disables the slice and configures it as a free-running counter with
+// the default divider (1), the maximum period (65536 ticks) and both outputs
+// low. Mode is a combination of the PH_CORRECT, A_INV, B_INV flags.
+func (s Slice) Setup(mode CSR) {
+	r := s.Regs()
+	r.CSR.Store(mode &^ (EN | DIVMODE | PH_RET | PH_ADV))
+	r.DIV.Store(1 << INTn)
+	r.CC.Store(0)
+	r.TOP.Store(0xffff)
+	r.CTR.Store(0)
+}
+
+// Enable enables the slice. Use Periph.Enable to start multiple slices in sync.
+func (s Slice) Enable() {
+	internal.AtomicSet(&s.Regs().CSR, EN)
+}
+
+// Disable disables the slice. The outputs keep their current state.
+func (s Slice) Disable() {
+	internal.AtomicClear(&s.Regs().CSR, EN)
+}
+
+// Enabled reports whether the slice is enabled.
+func (s Slice) Enabled() bool {
+	return s.Regs().CSR.LoadBits(EN) != 0
+}
+
+// SetPhaseCorrect enables or disables the phase-correct mode in which the
+// counter counts up to Top and then down to zero, so the output pulses are
+// centered and the PWM frequency is halved.
+func (s Slice) SetPhaseCorrect(pc bool) {
+	r := s.Regs()
+	if pc {
+		internal.AtomicSet(&r.CSR, PH_CORRECT)
+	} else {
+		internal.AtomicClear(&r.CSR, PH_CORRECT)
+	}
+}
+
+// SetDiv sets the clock divider to div16/16 (4-bit fraction). The valid range
+// for div16 is from 16 (1.0) to 4095 (255.9375).
+func (s Slice) SetDiv(div16 int) {
+	if div16 < 1<<4 || div16 > int(INT|FRAC) {
+		panic("pwm: bad divider")
+	}
+	s.Regs().DIV.Store(uint32(div16))
+}
+
+// Div returns the current clock divider multiplied by 16.
+func (s Slice) Div() int {
+	div16 := int(s.Regs().DIV.Load() & (INT | FRAC))
+	if div16>>INTn == 0 {
+		div16 += 256 << INTn // INT=0 means 256
+	}
+	return div16
+}
+
+// SetTop sets the counter wrap value. The PWM period is top+1 ticks (2*(top+1)
+// ticks in the phase-correct mode). The 100% duty cycle is possible only if
+// top <= 65534 (see Channel.SetTicks).
+func (s Slice) SetTop(top int) {
+	s.Regs().TOP.Store(uint32(uint16(top)))
+}
+
+// Top returns the counter wrap value.
+func (s Slice) Top() int {
+	return int(s.Regs().TOP.Load() & 0xffff)
+}
+
+// Counter returns the current value of the slice counter.
+func (s Slice) Counter() int {
+	return int(s.Regs().CTR.Load() & 0xffff)
+}
+
+// SetCounter sets the slice counter.
+func (s Slice) SetCounter(cnt int) {
+	s.Regs().CTR.Store(uint32(uint16(cnt)))
+}
+
+func periodMul(r *SliceRegs) int64 {
+	return int64(1 + r.CSR.LoadBits(PH_CORRECT)>>PH_CORRECTn)
+}
+
+// Freq returns the PWM frequency in Hz.
+func (s Slice) Freq() int {
+	r := s.Regs()
+	period := int64(s.Div()) * int64(s.Top()+1) * periodMul(r)
+	return int((clock.SYS.Freq()<<4 + period/2) / period)
+}
+
+// SetFreq sets the PWM frequency to the value as close to hz as possible. It
+// selects the smallest clock divider (the best duty cycle resolution) that
+// allows to achieve the required frequency. The compare values of both
+// channels are scaled to preserve the duty cycle. SetFreq returns the actual
+// frequency or -1 if the hz is out of the range supported by the current
+// system clock.
+func (s Slice) SetFreq(hz int) (actual int) {
+	if hz <= 0 {
+		return -1
+	}
+	r := s.Regs()
+	clk16 := clock.SYS.Freq() << 4
+	f := int64(hz) * periodMul(r)
+	div16 := (clk16 + f<<16 - 1) / (f << 16) // ceil(clk16 / (f * 65536))
+	if div16 < 1<<4 {
+		div16 = 1 << 4
+	} else if div16 > int64(INT|FRAC) {
+		return -1
+	}
+	top := (clk16+div16*f/2)/(div16*f) - 1
+	if top < 1 {
+		return -1
+	}
+	oldTop := int64(s.Top())
+	cc := r.CC.Load()
+	a := (int64(cc&0xffff)*(top+1) + oldTop/2) / (oldTop + 1)
+	b := (int64(cc>>16)*(top+1) + oldTop/2) / (oldTop + 1)
+	r.DIV.Store(uint32(div16))
+	r.TOP.Store(uint32(top))
+	r.CC.Store(uint32(a) | uint32(b)<<16)
+	return s.Freq()
+}
+
+// Channel returns the channel n of the slice (0 for A, 1 for B).
+func (s Slice) Channel(n int) Channel {
+	if uint(n) > 1 {
+		panic("wrong PWM channel number")
+	}
+	return Channel{s, n}
+}
+
+// Channel represents a PWM output channel: A or B output of a slice.
+type Channel struct {
+	s Slice
+	n int
+}
+
+// Slice returns the slice that the channel belongs to.
+func (c Channel) Slice() Slice {
+	return c.s
+}
+
+// Num returns the channel number in the slice (0 for A, 1 for B).
+func (c Channel) Num() int {
+	return c.n
+}
+
+// SetTicks sets the compare value of the channel. The output is high while the
+// counter is below ticks, so ticks=0 means 0% duty cycle and ticks > Top means
+// 100% duty cycle. The compare value is 16-bit so ticks is clamped to 65535 and
+// the 100% duty cycle can't be set if Top is 65535.
+func (c Channel) SetTicks(ticks int) {
+	if ticks > 0xffff {
+		ticks = 0xffff
+	} else if ticks < 0 {
+		ticks = 0
+	}
+	r := c.s.Regs()
+	sh := uint(c.n * 16)
+	internal.AtomicModU32(&r.CC, 0xffff<<sh, r.CC.Load(), uint32(ticks)<<sh)
+}
+
+// Ticks returns the compare value of the channel.
+func (c Channel) Ticks() int {
+	return int(c.s.Regs().CC.Load() >> uint(c.n*16) & 0xffff)
+}
+
+// SetDuty sets the duty cycle of the channel to the ratio num/den (e.g.
+// SetDuty(1, 4) sets 25% duty cycle). Call SetDuty after SetFreq or SetTop.
+// SetDuty panics if den <= 0.
+func (c Channel) SetDuty(num, den int) {
+	if den <= 0 {
+		panic("pwm: bad duty cycle denominator")
+	}
+	period := int64(c.s.Top() + 1)
+	c.SetTicks(int((int64(num)*period + int64(den)/2) / int64(den)))
+}
+
+// SetInvert enables or disables the inversion of the channel output.
+func (c Channel) SetInvert(inv bool) {
+	r := c.s.Regs()
+	mask := A_INV << uint(c.n)
+	if inv {
+		internal.AtomicSet(&r.CSR, mask)
+	} else {
+		internal.AtomicClear(&r.CSR, mask)
+	}
+}
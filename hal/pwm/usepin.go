@@ -0,0 +1,39 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pwm
+
+import "github.com/embeddedgo/pico/hal/iomux"
+
+// PinChannel returns the PWM channel connected to the IO pin. GPIO0 to GPIO15
+// are connected to the 0A-7B channels, GPIO16 to GPIO31 also to the 0A-7B
+// channels, GPIO32 to GPIO47 are connected twice to the 8A-11B channels.
+func (p *Periph) PinChannel(pin iomux.Pin) Channel {
+	n := int(pin) >> 1
+	if pin < 32 {
+		n &= 7
+	} else {
+		n = 8 + n&3
+	}
+	return p.Slice(n).Channel(int(pin) & 1)
+}
+
+// Pins returns the IO pins connected to the channel c.
+func (c Channel) Pins() []iomux.Pin {
+	n := c.s.n
+	if n < 8 {
+		pin := iomux.Pin(n*2 + c.n)
+		return []iomux.Pin{pin, pin + 16}
+	}
+	pin := iomux.Pin(32 + (n-8)*2 + c.n)
+	return []iomux.Pin{pin, pin + 8}
+}
+
+// UsePin is a helper function that can be used to configure an IO pin as the
+// PWM output. It returns the PWM channel connected to the pin.
+func (p *Periph) UsePin(pin iomux.Pin) Channel {
+	pin.SetAltFunc(pin.AltFunc()&^iomux.Func | iomux.PWM)
+	pin.Setup(iomux.D4mA)
+	return p.PinChannel(pin)
+}
@@ -0,0 +1,6 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file allows the bodyless declarations of the functions provided by the
+// clock package (see go:linkname in hal/system/clock).
@@ -0,0 +1,211 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resus provides the supervision of the SYS clock using the clock
+// resuscitation (RESUS) circuit of the clock controller.
+//
+// If enabled, the RESUS detects that the SYS clock stopped (e.g. PLL_SYS lost
+// its lock or the SYS aux source was misconfigured) and forces SYS to run from
+// the REF clock. The CLOCKS interrupt handler provided by this package
+// completes the switch to REF, updates the clock frequencies known by the
+// clock package and wakes up the goroutines waiting in Wait. The application
+// can log the fault and try to restore the original clock configuration using
+// Relock.
+//
+// The RESUS can't help if the REF clock itself is stopped, e.g. if REF runs
+// from the failed XOSC. Relock refuses to restore the clocks if the XOSC isn't
+// stable.
+package resus
+
+import (
+	"embedded/rtos"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/hal/watchdog"
+	"github.com/embeddedgo/pico/p/clocks"
+	"github.com/embeddedgo/pico/p/xosc"
+)
+
+const (
+	intSysResus = 1 << 0 // CLK_SYS_RESUS bit in the INTR, INTE, INTS registers
+	resussed    = 1 << 0 // RESUSSED bit in the SYS_RESUS_STATUS register
+
+	recordMagic = 0x52455355 // "RESU"
+)
+
+var (
+	irqOnce sync.Once
+	mu      sync.Mutex
+	ctrl    clocks.SYS_RESUS_CTRL
+	record  bool
+	count   atomic.Uint32
+	event   rtos.Note
+)
+
+// Enable enables the SYS clock resuscitation. The timeout specifies for how
+// long the SYS clock must be stopped to trigger the RESUS. It's rounded to the
+// number of REF clock cycles which must be in the range from 1 to 255 (e.g.
+// up to 21 µs for the 12 MHz REF). Use timeout <= 0 to select the maximum
+// possible value. If record is true the RESUS events are counted in the
+// watchdog Scratch0 and Scratch1 registers so they can be reported after the
+// reboot using Recorded.
+//
+// The system.Setup and system.RestoreClocks functions disable the RESUS.
+// Relock re-enables it.
+func Enable(timeout time.Duration, record bool) {
+	cycles := int64(clocks.TIMEOUT)
+	if timeout > 0 {
+		cycles = (clock.REF.Freq()*int64(timeout) + 5e8) / 1e9
+		if cycles < 1 || cycles > int64(clocks.TIMEOUT) {
+			panic("resus: bad timeout")
+		}
+	}
+	irqOnce.Do(func() {
+		irq.CLOCKS.Enable(rtos.IntPrioHighest, system.NextCPU())
+	})
+	mu.Lock()
+	ctrl = clocks.ENABLE | clocks.SYS_RESUS_CTRL(cycles)
+	setRecord(record)
+	enable()
+	mu.Unlock()
+}
+
+func setRecord(rec bool) {
+	record = rec
+	if rec && watchdog.Scratch0.Load() != recordMagic {
+		watchdog.Scratch1.Store(0)
+		watchdog.Scratch0.Store(recordMagic)
+	}
+}
+
+func enable() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := clocks.CLOCKS()
+	c.SYS_RESUS_CTRL.Store(ctrl)
+	internal.AtomicSet(&c.INTE, intSysResus)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Disable disables the SYS clock resuscitation.
+func Disable() {
+	mu.Lock()
+	ctrl = 0
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := clocks.CLOCKS()
+	internal.AtomicClear(&c.INTE, intSysResus)
+	c.SYS_RESUS_CTRL.Store(0)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	mu.Unlock()
+}
+
+// Force forces the RESUS event. Use it only for testing.
+func Force() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	internal.AtomicSet(&clocks.CLOCKS().SYS_RESUS_CTRL, clocks.FRCE)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Count returns the number of RESUS events since the program start.
+func Count() int {
+	return int(count.Load())
+}
+
+// Wait waits for the RESUS event. It reports whether the event occurred before
+// the timeout. The negative timeout means no timeout. Before returning true
+// Wait informs the registered clock notifiers (see clock.AddNotifier) about
+// the changed SYS and PERI frequencies so the drivers (e.g. UART) can be used
+// to report the fault.
+func Wait(timeout time.Duration) bool {
+	if !event.Sleep(timeout) {
+		return false
+	}
+	event.Clear()
+	clocksChanged()
+	return true
+}
+
+// ErrXOSC is returned by Relock if the crystal oscillator isn't running.
+var ErrXOSC = errors.New("resus: XOSC not stable")
+
+// Relock tries to restore the clock configuration set by system.Setup (see
+// system.RestoreClocks) and re-enables the RESUS if it was enabled.
+func Relock() error {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	stable := xosc.XOSC().STATUS.LoadBits(xosc.STABLE) != 0
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	if !stable {
+		return ErrXOSC
+	}
+	mu.Lock()
+	system.RestoreClocks()
+	if ctrl != 0 {
+		enable()
+	}
+	mu.Unlock()
+	return nil
+}
+
+// Recorded returns the number of RESUS events recorded in the watchdog
+// scratch registers (see Enable) before the last watchdog reboot. It returns
+// 0 if nothing was recorded. Use ClearRecord to reset the counter.
+func Recorded() int {
+	if watchdog.Scratch0.Load() != recordMagic {
+		return 0
+	}
+	return int(watchdog.Scratch1.Load())
+}
+
+// ClearRecord clears the RESUS events counter in the watchdog scratch
+// registers.
+func ClearRecord() {
+	watchdog.Scratch1.Store(0)
+}
+
+func clocksResussed()
+func clocksChanged()
+
+//go:interrupthandler
+func _CLOCKS_Handler() {
+	c := clocks.CLOCKS()
+	if c.INTS.Load()&intSysResus == 0 {
+		return
+	}
+	// Switch SYS cleanly to REF (the RESUS only forces it).
+	sys := &c.CLK[clocks.SYS]
+	internal.AtomicClear(&sys.CTRL, clocks.SYS_SRC)
+	for sys.SELECTED.LoadBits(0b11) != 0b01 {
+	}
+	clocksResussed()
+	// Now it is safe to clear the RESUS.
+	internal.AtomicClear(&c.SYS_RESUS_CTRL, clocks.FRCE)
+	internal.AtomicSet(&c.SYS_RESUS_CTRL, clocks.CLEAR)
+	for c.SYS_RESUS_STATUS.Load()&resussed != 0 {
+	}
+	internal.AtomicClear(&c.SYS_RESUS_CTRL, clocks.CLEAR)
+	count.Add(1)
+	if record {
+		wd := watchdog.WATCHDOG()
+		wd.SCRATCH[1].Store(wd.SCRATCH[1].Load() + 1)
+	}
+	event.Wakeup()
+}
+
+//go:linkname _CLOCKS_Handler IRQ30_Handler
@@ -0,0 +1,28 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha256
+
+type CSR uint32
+
+const (
+	START             CSR = 0x01 << 0  //+ Prepare the core for a new checksum.
+	WDATA_RDY         CSR = 0x01 << 1  //+ The core is ready to accept more data.
+	SUM_VLD           CSR = 0x01 << 2  //+ The checksum in the SUM registers is valid.
+	ERR_WDATA_NOT_RDY CSR = 0x01 << 4  //+ WDATA written when WDATA_RDY was low.
+	DMA_SIZE          CSR = 0x03 << 8  //+ DREQ logic configuration for DMA data size:
+	DMA_S8b           CSR = 0x00 << 8  //  - byte
+	DMA_S16b          CSR = 0x01 << 8  //  - half word
+	DMA_S32b          CSR = 0x02 << 8  //  - word
+	BSWAP             CSR = 0x01 << 12 //+ Byte swap the 32-bit words written to the core.
+)
+
+const (
+	STARTn             = 0
+	WDATA_RDYn         = 1
+	SUM_VLDn           = 2
+	ERR_WDATA_NOT_RDYn = 4
+	DMA_SIZEn          = 8
+	BSWAPn             = 12
+)
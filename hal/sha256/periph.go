@@ -0,0 +1,26 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha256
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	CSR   mmio.R32[CSR]
+	WDATA mmio.U32
+	SUM   [8]mmio.U32
+}
+
+// SHA256 returns the SHA-256 accelerator peripheral.
+func SHA256() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.SHA256_BASE))
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sha256 implements the SHA-256 hash function using the RP2350
+// hardware accelerator.
+//
+// The full 64-byte blocks are processed by the accelerator, fed by the CPU or,
+// for larger buffers, by the DMA channel set using UseDMA. The accelerator has
+// only one hash state and this state can't be restored, so there is only one
+// hash (the owner of the accelerator) that can use it at a time. If another
+// hash needs the accelerator the current owner is moved to the software
+// implementation (crypto/sha256) and continues there until Reset.
+//
+// The Sum method doesn't disturb the accelerator. It hashes the last partial
+// block and the padding in software, starting from the intermediate hash
+// value read from the accelerator.
+package sha256
+
+import (
+	"crypto/sha256"
+	"embedded/rtos"
+	"encoding"
+	"encoding/binary"
+	"hash"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/dma"
+	"github.com/embeddedgo/pico/hal/internal"
+)
+
+// The size of a SHA-256 checksum in bytes.
+const Size = 32
+
+// The blocksize of SHA-256 in bytes.
+const BlockSize = 64
+
+// Using the DMA for short buffers doesn't pay off.
+const minDMA = 4 * BlockSize
+
+var engine struct {
+	sync.Mutex
+	owner *digest
+	dma   dma.Channel
+	open  bool
+}
+
+// openEngine allows the unprivileged access to the accelerator, which by
+// default is accessible only in the privileged mode. The DMA needs this too
+// because the dma package configures all channels as unprivileged. It must be
+// called with the engine locked.
+func openEngine() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	internal.AccessGrant(internal.AccSHA256, internal.AccSU|internal.AccDMA)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	engine.open = true
+}
+
+// UseDMA sets the DMA channel used to feed the accelerator with big chunks of
+// data. Use an invalid channel (zero value) to stop using the DMA.
+func UseDMA(ch dma.Channel) {
+	engine.Lock()
+	engine.dma = ch
+	engine.Unlock()
+}
+
+type digest struct {
+	x   [BlockSize]byte
+	nx  int
+	len uint64
+	hw  bool      // the accelerator contains the state of this digest
+	sw  hash.Hash // software continuation (see spill)
+}
+
+// New returns a new hash.Hash computing the SHA-256 checksum using the
+// hardware accelerator.
+func New() hash.Hash {
+	return new(digest)
+}
+
+func (d *digest) Size() int      { return Size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Reset() {
+	engine.Lock()
+	if engine.owner == d {
+		engine.owner = nil
+	}
+	d.nx = 0
+	d.len = 0
+	d.hw = false
+	d.sw = nil
+	engine.Unlock()
+}
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	engine.Lock()
+	if d.sw != nil {
+		engine.Unlock()
+		return d.sw.Write(p)
+	}
+	d.len += uint64(n)
+	if d.nx > 0 {
+		m := copy(d.x[d.nx:], p)
+		d.nx += m
+		p = p[m:]
+		if d.nx == BlockSize {
+			d.blocks(d.x[:])
+			d.nx = 0
+		}
+	}
+	if len(p) >= BlockSize {
+		m := len(p) &^ (BlockSize - 1)
+		d.blocks(p[:m])
+		p = p[m:]
+	}
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	engine.Unlock()
+	return
+}
+
+// blocks writes the full blocks to the accelerator. It must be called with
+// the engine locked.
+func (d *digest) blocks(p []byte) {
+	p0 := SHA256()
+	if !engine.open {
+		openEngine()
+	}
+	if !d.hw {
+		if o := engine.owner; o != nil {
+			o.spill()
+		}
+		engine.owner = d
+		d.hw = true
+		p0.CSR.Store(BSWAP | DMA_S32b | START)
+	}
+	if ch := engine.dma; ch.IsValid() && len(p) >= minDMA {
+		blocksDMA(ch, p)
+		return
+	}
+	for len(p) >= BlockSize {
+		for p0.CSR.LoadBits(WDATA_RDY) == 0 {
+		}
+		// The bus interface assembles words in little-endian order and BSWAP
+		// makes the first byte the most significant one as SHA-256 requires.
+		for i := 0; i < BlockSize; i += 4 {
+			p0.WDATA.Store(binary.LittleEndian.Uint32(p[i:]))
+		}
+		p = p[BlockSize:]
+	}
+}
+
+func blocksDMA(ch dma.Channel, p []byte) {
+	p0 := SHA256()
+	cfg := dma.En | dma.SHA256 | dma.IncR
+	n := len(p)
+	if uintptr(unsafe.Pointer(&p[0]))&3 == 0 {
+		cfg |= dma.S32b
+		p0.CSR.StoreBits(DMA_SIZE, DMA_S32b)
+		n /= 4
+	} else {
+		cfg |= dma.S8b
+		p0.CSR.StoreBits(DMA_SIZE, DMA_S8b)
+	}
+	for p0.CSR.LoadBits(WDATA_RDY) == 0 {
+	}
+	ch.SetReadAddr(unsafe.Pointer(&p[0]))
+	ch.SetWriteAddr(unsafe.Pointer(&p0.WDATA))
+	ch.SetTransCount(n, dma.Normal)
+	ch.SetConfigTrig(cfg, ch)
+	for ch.Status()&dma.Busy != 0 {
+		runtime.Gosched()
+	}
+	runtime.KeepAlive(p)
+	p0.CSR.StoreBits(DMA_SIZE, DMA_S32b)
+}
+
+// intermediate returns the intermediate hash value of the blocks written to
+// the accelerator.
+func intermediate() (h [8]uint32) {
+	p := SHA256()
+	for p.CSR.LoadBits(SUM_VLD) == 0 {
+	}
+	for i := range h {
+		h[i] = p.SUM[i].Load()
+	}
+	return
+}
+
+// software returns the crypto/sha256 hash initialized with the intermediate
+// hash value h, the unprocessed bytes x and the total length n.
+func software(h *[8]uint32, x []byte, n uint64) hash.Hash {
+	// Use the crypto/sha256 marshaled state format.
+	b := make([]byte, 0, 4+Size+BlockSize+8)
+	b = append(b, "sha\x03"...)
+	for _, v := range h {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	b = append(b, x...)
+	b = b[:len(b)+BlockSize-len(x)]
+	b = binary.BigEndian.AppendUint64(b, n)
+	s := sha256.New()
+	if err := s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// spill moves the state of the accelerator owner to the software hash. It
+// must be called with the engine locked.
+func (d *digest) spill() {
+	h := intermediate()
+	d.sw = software(&h, d.x[:d.nx], d.len)
+	d.hw = false
+	engine.owner = nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	engine.Lock()
+	var s hash.Hash
+	switch {
+	case d.sw != nil:
+		s = d.sw
+	case d.hw:
+		h := intermediate()
+		s = software(&h, d.x[:d.nx], d.len)
+	default:
+		s = sha256.New()
+		s.Write(d.x[:d.nx])
+	}
+	engine.Unlock()
+	return s.Sum(in)
+}
+
+// Sum256 returns the SHA-256 checksum of the data.
+func Sum256(data []byte) (sum [Size]byte) {
+	d := new(digest)
+	d.Write(data)
+	d.Sum(sum[:0])
+	d.Reset()
+	return
+}
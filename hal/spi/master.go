@@ -29,6 +29,8 @@ type Master struct {
 
 	irqn int
 	done rtos.Note
+
+	baud int
 }
 
 // NewMaster returns a new master-mode driver for p. If valid DMA channels are
@@ -45,6 +47,7 @@ func NewMaster(p *Periph, rdma, wdma dma.Channel) *Master {
 		d.wdc = dma.En | (dma.SPI0_TX + reqAdd)
 		wdma.SetWriteAddr(unsafe.Pointer(&p.DR))
 	}
+	clock.AddNotifier(d)
 	return d
 }
 
@@ -122,6 +125,8 @@ func (d *Master) Baudrate() int {
 	return int((uint(2*clock.PERI.Freq()/div) + 1) / 2)
 }
 
+// SetBaudrate sets the SPI clock frequency. The baudrate is recalculated
+// automatically if the PERI clock frequency changes (see clock.SetSys).
 func (d *Master) SetBaudrate(baudrate int) (actual int) {
 	if baudrate <= 0 {
 		return -1
@@ -145,6 +150,7 @@ func (d *Master) SetBaudrate(baudrate int) (actual int) {
 			return -1
 		}
 	}
+	d.baud = baudrate
 	d.WaitTxDone()
 	p := d.p
 	p.CPSR.Store(uint32(cpsr))
@@ -155,6 +161,16 @@ func (d *Master) SetBaudrate(baudrate int) (actual int) {
 	return
 }
 
+// ClockChanged implements the clock.Notifier interface. It doesn't synchronize
+// with the transfers started by other goroutines (it waits only for the Tx FIFO
+// to become empty) so an ongoing DMA or interrupt driven transfer can continue
+// with the new clock before its end. Change the clock when the SPI is idle.
+func (d *Master) ClockChanged(clk clock.Clock) {
+	if clk == clock.PERI && d.baud > 0 {
+		d.SetBaudrate(d.baud)
+	}
+}
+
 // Setup resets the underlying SPI peripheral and configures it according to
 // the master driver needs. Next it calls the SetConfig and SetBaudrate methods
 // with the provided arguments and enables the peripheral.
@@ -0,0 +1,205 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/p/clocks"
+)
+
+// A Notifier is notified about the clock frequency changes. Drivers that
+// depend on the clock frequency (e.g. the baudrate dividers) implement this
+// interface to recalculate their configuration.
+type Notifier interface {
+	// ClockChanged is called after the frequency of clk was changed. It's
+	// called in the thread mode by the goroutine that changed the clock so it
+	// should not wait for the driver to become idle (the caller may be the
+	// goroutine that uses the driver).
+	ClockChanged(clk Clock)
+}
+
+var notifiers struct {
+	sync.Mutex
+	list []Notifier
+}
+
+// AddNotifier registers n to be notified about the clock frequency changes.
+// Adding the same notifier more than once has no effect.
+func AddNotifier(n Notifier) {
+	notifiers.Lock()
+	for _, e := range notifiers.list {
+		if e == n {
+			notifiers.Unlock()
+			return
+		}
+	}
+	notifiers.list = append(notifiers.list, n)
+	notifiers.Unlock()
+}
+
+// RemoveNotifier unregisters n.
+func RemoveNotifier(n Notifier) {
+	notifiers.Lock()
+	list := notifiers.list
+	for i, e := range list {
+		if e == n {
+			copy(list[i:], list[i+1:])
+			list[len(list)-1] = nil
+			notifiers.list = list[:len(list)-1]
+			break
+		}
+	}
+	notifiers.Unlock()
+}
+
+// notify calls the registered notifiers. They're called without holding the
+// notifiers mutex so a notifier may block or (un)register notifiers.
+func notify(clk Clock) {
+	notifiers.Lock()
+	list := append([]Notifier(nil), notifiers.list...)
+	notifiers.Unlock()
+	for _, n := range list {
+		n.ClockChanged(clk)
+	}
+}
+
+// notifyAll is called by system.RestoreClocks.
+func notifyAll() {
+	notify(SYS)
+	notify(PERI)
+}
+
+//go:linkname notifyAll github.com/embeddedgo/pico/hal/system.clocksRestored
+
+// sysResussed is called by the resus interrupt handler after the SYS clock
+// was switched to REF.
+//
+//go:nosplit
+func sysResussed() {
+	sysHz := clocksHz[REF]
+	clocksHz[SYS] = sysHz
+	followSys(PERI, clocks.PERI_CLK_SYS, int64(sysHz))
+	followSys(HSTX, clocks.HSTX_CLK_SYS, int64(sysHz))
+}
+
+//go:linkname sysResussed github.com/embeddedgo/pico/hal/resus.clocksResussed
+
+// resusNotify is called by resus.Wait in the thread mode.
+func resusNotify() {
+	notifyAll()
+}
+
+//go:linkname resusNotify github.com/embeddedgo/pico/hal/resus.clocksChanged
+
+var changeMu sync.Mutex
+
+// SetSys changes the frequency of the SYS clock at runtime by changing its
+// integer divider. The SYS clock source (usually PLL_SYS) remains untouched so
+// the frequency can be only lowered with respect to the one configured by the
+// system.Setup function. Use hz <= 0 to restore the undivided frequency. The
+// PERI and HSTX clocks, if driven by SYS, follow the SYS clock. The registered
+// notifiers are informed about the new frequencies. SetSys returns the actual
+// SYS frequency.
+//
+// The divider is changed on the fly (the SYS clock never stops) and the flash
+// interface timing, calculated for the undivided frequency, remains valid.
+// The clock change may disturb ongoing transfers of the peripherals clocked
+// by SYS or PERI.
+func SetSys(hz int64) (actualHz int64) {
+	changeMu.Lock()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := &clocks.CLOCKS().CLK[clocks.SYS]
+	div := int64(c.DIV.Load() >> clocks.SYS_INTn)
+	if div == 0 {
+		div = 1 << 16
+	}
+	srcHz := int64(clocksHz[SYS]) * div
+	div = 1
+	if hz > 0 {
+		div = (srcHz + hz - 1) / hz
+	}
+	div = min(max(div, 1), 0xffff)
+	actualHz = srcHz / div
+	changed := actualHz != int64(clocksHz[SYS])
+	periChanged := false
+	if changed {
+		c.DIV.Store(clocks.DIV(div) << clocks.SYS_INTn)
+		clocksHz[SYS] = uint(actualHz)
+		periChanged = followSys(PERI, clocks.PERI_CLK_SYS, actualHz)
+		followSys(HSTX, clocks.HSTX_CLK_SYS, actualHz)
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	changeMu.Unlock()
+	if changed {
+		notify(SYS)
+		if periChanged {
+			notify(PERI)
+		}
+	}
+	return actualHz
+}
+
+// followSys updates the stored frequency of clk if it's driven by SYS.
+//
+//go:nosplit
+func followSys(clk Clock, auxsrc clocks.CTRL, sysHz int64) bool {
+	c := &clocks.CLOCKS().CLK[clk]
+	if c.CTRL.LoadBits(clocks.PERI_AUXSRC|clocks.PERI_ENABLE) != auxsrc|clocks.PERI_ENABLE {
+		return false
+	}
+	clocksHz[clk] = uint(sysHz) / divInt2(c.DIV.Load())
+	return true
+}
+
+// divInt2 decodes the 2-bit integer divider used by the PERI and HSTX clocks.
+//
+//go:nosplit
+func divInt2(d clocks.DIV) uint {
+	div := uint(d>>clocks.PERI_INTn) & 3
+	if div == 0 {
+		div = 4
+	}
+	return div
+}
+
+// SetPeri changes the PERI clock divider (1 to 4) at runtime so the PERI
+// frequency is as close as possible but not greater than hz. Use hz <= 0 to
+// set the divider to 1. The registered notifiers are informed about the new
+// frequency. SetPeri returns the actual PERI frequency or -1 if the PERI clock
+// is disabled.
+func SetPeri(hz int64) (actualHz int64) {
+	changeMu.Lock()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := &clocks.CLOCKS().CLK[clocks.PERI]
+	actualHz = -1
+	changed := false
+	if c.CTRL.LoadBits(clocks.PERI_ENABLE) != 0 {
+		srcHz := int64(clocksHz[PERI] * divInt2(c.DIV.Load()))
+		div := int64(1)
+		if hz > 0 {
+			div = min(max((srcHz+hz-1)/hz, 1), 4)
+		}
+		actualHz = srcHz / div
+		changed = actualHz != int64(clocksHz[PERI])
+		if changed {
+			c.DIV.Store(clocks.DIV(div&3) << clocks.PERI_INTn)
+			clocksHz[PERI] = uint(actualHz)
+		}
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	changeMu.Unlock()
+	if changed {
+		notify(PERI)
+	}
+	return actualHz
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/clocks"
+	"github.com/embeddedgo/pico/p/iobank"
+	"github.com/embeddedgo/pico/p/padsbank"
+)
+
+// Source represents a clock source. The Source values are the same as used by
+// the frequency counter.
+type Source uint8
+
+const (
+	PLL_SYS    = Source(clocks.FC0_PLL_SYS_CLKSRC_PRIMARY)
+	PLL_USB    = Source(clocks.FC0_PLL_USB_CLKSRC_PRIMARY)
+	ROSC       = Source(clocks.FC0_ROSC_CLKSRC)
+	ROSC_PH    = Source(clocks.FC0_ROSC_CLKSRC_PH) // phase shifted ROSC (FC0 only)
+	XOSC       = Source(clocks.FC0_XOSC_CLKSRC)
+	GPIN0      = Source(clocks.FC0_CLKSRC_GPIN0)
+	GPIN1      = Source(clocks.FC0_CLKSRC_GPIN1)
+	CLK_REF    = Source(clocks.FC0_CLK_REF)
+	CLK_SYS    = Source(clocks.FC0_CLK_SYS)
+	CLK_PERI   = Source(clocks.FC0_CLK_PERI)
+	CLK_USB    = Source(clocks.FC0_CLK_USB)
+	CLK_ADC    = Source(clocks.FC0_CLK_ADC)
+	CLK_HSTX   = Source(clocks.FC0_CLK_HSTX)
+	LPOSC      = Source(clocks.FC0_LPOSC_CLKSRC)
+	OTP_CLK2FC = Source(clocks.FC0_OTP_CLK2FC)
+)
+
+// gpoutSrc maps Source to the GPOUT AUXSRC field value (0xff means invalid).
+var gpoutSrc = [...]uint8{
+	0xff,
+	PLL_SYS:    uint8(clocks.GPOUT_CLKSRC_PLL_SYS >> clocks.GPOUT_AUXSRCn),
+	PLL_USB:    uint8(clocks.GPOUT_CLKSRC_PLL_USB >> clocks.GPOUT_AUXSRCn),
+	ROSC:       uint8(clocks.GPOUT_ROSC_CLKSRC >> clocks.GPOUT_AUXSRCn),
+	ROSC_PH:    0xff,
+	XOSC:       uint8(clocks.GPOUT_XOSC_CLKSRC >> clocks.GPOUT_AUXSRCn),
+	GPIN0:      uint8(clocks.GPOUT_CLKSRC_GPIN0 >> clocks.GPOUT_AUXSRCn),
+	GPIN1:      uint8(clocks.GPOUT_CLKSRC_GPIN1 >> clocks.GPOUT_AUXSRCn),
+	CLK_REF:    uint8(clocks.GPOUT_CLK_REF >> clocks.GPOUT_AUXSRCn),
+	CLK_SYS:    uint8(clocks.GPOUT_CLK_SYS >> clocks.GPOUT_AUXSRCn),
+	CLK_PERI:   uint8(clocks.GPOUT_CLK_PERI >> clocks.GPOUT_AUXSRCn),
+	CLK_USB:    uint8(clocks.GPOUT_CLK_USB >> clocks.GPOUT_AUXSRCn),
+	CLK_ADC:    uint8(clocks.GPOUT_CLK_ADC >> clocks.GPOUT_AUXSRCn),
+	CLK_HSTX:   uint8(clocks.GPOUT_CLK_HSTX >> clocks.GPOUT_AUXSRCn),
+	LPOSC:      uint8(clocks.GPOUT_LPOSC_CLKSRC >> clocks.GPOUT_AUXSRCn),
+	OTP_CLK2FC: uint8(clocks.GPOUT_OTP_CLK2FC >> clocks.GPOUT_AUXSRCn),
+}
+
+// Freq returns the frequency of src. It returns the frequency stored by the
+// clock configuration functions for the CLK_* sources. For the other sources
+// the frequency is measured using the frequency counter (see Measure).
+func (src Source) Freq() (hz int64) {
+	switch src {
+	case CLK_REF:
+		return REF.Freq()
+	case CLK_SYS:
+		return SYS.Freq()
+	case CLK_PERI:
+		return PERI.Freq()
+	case CLK_USB:
+		return USB.Freq()
+	case CLK_ADC:
+		return ADC.Freq()
+	case CLK_HSTX:
+		return HSTX.Freq()
+	}
+	return Measure(src)
+}
+
+// gpoutPins contains the GPIO numbers that can be used as GPOUT0, GPOUT1,
+// GPOUT2, GPOUT3 outputs.
+var gpoutPins = [4][2]int8{{13, 21}, {15, 23}, {24, -1}, {25, -1}}
+
+// Setup configures the GPOUTn clock generator to output the src clock divided
+// by div. The div is a 16.16 fixed point number (div = 1<<16 means divide by 1,
+// 0 means divide by 1<<16). If pin >= 0 Setup configures the GPIO pin with the
+// given number (e.g. int(iomux.P21)) as the clock output. The allowed pins are:
+// GPIO13 and GPIO21 for GPOUT0, GPIO15 and GPIO23 for GPOUT1, GPIO24 for GPOUT2
+// and GPIO25 for GPOUT3. Setup returns the output frequency or -1 if clk isn't
+// a GPOUTn clock or the source or the pin can't be used.
+func (clk Clock) Setup(src Source, div uint32, pin int) (hz int64) {
+	if uint(clk) > uint(GPOUT3) || int(src) >= len(gpoutSrc) ||
+		gpoutSrc[src] == 0xff {
+		return -1
+	}
+	if pin >= 0 {
+		if p := gpoutPins[clk]; pin != int(p[0]) && pin != int(p[1]) {
+			return -1
+		}
+	}
+	srcHz := src.Freq()
+	if srcHz <= 0 {
+		return -1
+	}
+	d := int64(div)
+	if d == 0 {
+		d = 1 << 32
+	}
+	hz = srcHz << 16 / d
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	auxsrc := clocks.CTRL(gpoutSrc[src]) << clocks.GPOUT_AUXSRCn
+	set(int(clk), 0, auxsrc, uint(hz), clocks.DIV(div))
+	c := &clocks.CLOCKS().CLK[clk]
+	if div&uint32(clocks.GPOUT_FRAC) == 0 {
+		internal.AtomicSet(&c.CTRL, clocks.GPOUT_DC50)
+	} else {
+		internal.AtomicClear(&c.CTRL, clocks.GPOUT_DC50)
+	}
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	if pin >= 0 {
+		padsbank.PADS_BANK0().GPIO[pin].Store(padsbank.D8MA | padsbank.SLEWFAST)
+		iobank.IO_BANK0().GPIO[pin].CTRL.Store(iobank.F9)
+	}
+	return hz
+}
+
+var fc0mu sync.Mutex
+
+// Measure measures the frequency of src using the frequency counter. The
+// measurement takes about 1 ms and its resolution is about 31 Hz. Measure
+// returns 0 if the source clock isn't running.
+func Measure(src Source) (hz int64) {
+	refKHz := uint32(clocksHz[REF] / 1000)
+	if refKHz == 0 || src == 0 || src > OTP_CLK2FC {
+		return 0
+	}
+	fc0mu.Lock()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	c := clocks.CLOCKS()
+	for c.FC0_STATUS.LoadBits(clocks.RUNNING) != 0 {
+	}
+	c.FC0_REF_KHZ.Store(refKHz)
+	c.FC0_INTERVAL.Store(10) // about 1 ms
+	c.FC0_MIN_KHZ.Store(0)
+	c.FC0_MAX_KHZ.Store(0xffffffff)
+	c.FC0_SRC.Store(clocks.FC0_SRC(src))
+	var st clocks.FC0_STATUS
+	for {
+		st = c.FC0_STATUS.Load()
+		if st&(clocks.DONE|clocks.DIED) != 0 {
+			break
+		}
+	}
+	if st&clocks.DIED == 0 {
+		hz = int64(c.FC0_RESULT.Load()) * 1000 >> 5 // 27.5 fixed point kHz
+	}
+	c.FC0_SRC.Store(clocks.FC0_NULL)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	fc0mu.Unlock()
+	return hz
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usbcon
+
+import (
+	"embedded/rtos"
+	"os"
+	"syscall"
+
+	"github.com/embeddedgo/fs/termfs"
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/cdc"
+)
+
+var acm *cdc.ACM
+
+func write(_ int, p []byte) int {
+	if rtos.HandlerMode() {
+		// The USB transfers require the interrupts so the output of print,
+		// println, panic called in handler mode is lost.
+		return len(p)
+	}
+	n, _ := acm.Write(p)
+	return n
+}
+
+func panicErr(err error) {
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+// Setup setups the USB device d to work as the system console. It creates the
+// CDC-ACM function, sets up d using info, the ACM function and the additional
+// functions (if any), connects d to the bus and returns the ACM function.
+func Setup(d *usb.Device, info *usb.DeviceInfo, name string, funcs ...usb.Function) *cdc.ACM {
+	// Setup the USB device.
+	a := cdc.NewACM(name)
+	d.Setup(info, append([]usb.Function{a}, funcs...)...)
+	d.Connect()
+
+	// Set a system writer for print, println, panic, etc.
+	acm = a
+	rtos.SetSystemWriter(write)
+
+	// Setup a serial console (standard input and output).
+	con := termfs.New(name, a, a)
+	con.SetCharMap(termfs.InCRLF | termfs.OutLFCRLF)
+	con.SetEcho(true)
+	con.SetLineMode(true, 256)
+	rtos.Mount(con, "/dev/console")
+	var err error
+	os.Stdin, err = os.OpenFile("/dev/console", syscall.O_RDONLY, 0)
+	panicErr(err)
+	os.Stdout, err = os.OpenFile("/dev/console", syscall.O_WRONLY, 0)
+	panicErr(err)
+	os.Stderr = os.Stdout
+	return a
+}
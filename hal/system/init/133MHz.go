@@ -6,12 +6,9 @@
 
 package init
 
-import (
-	"github.com/embeddedgo/pico/hal/system"
-	"github.com/embeddedgo/pico/hal/system/timer/riscvst"
-)
+import "github.com/embeddedgo/pico/hal/system"
 
 func init() {
 	system.SetupPico2_133MHz()
-	riscvst.Setup()
+	setupSystemTimer()
 }
@@ -0,0 +1,14 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nosysinit && 300MHz
+
+package init
+
+import "github.com/embeddedgo/pico/hal/system"
+
+func init() {
+	system.SetupPico2_300MHz()
+	setupSystemTimer()
+}
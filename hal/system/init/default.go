@@ -2,16 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build !nosysinit && !133MHz && !150MHz && !200MHz && !250MHz
+//go:build !nosysinit && !133MHz && !150MHz && !200MHz && !250MHz && !300MHz
 
 package init
 
-import (
-	"github.com/embeddedgo/pico/hal/system"
-	"github.com/embeddedgo/pico/hal/system/timer/riscvst"
-)
+import "github.com/embeddedgo/pico/hal/system"
 
 func init() {
 	system.SetupPico2_125MHz()
-	riscvst.Setup()
+	setupSystemTimer()
 }
@@ -13,11 +13,19 @@
 // expose problems with some cheap Pico 2 clones (the reason is mainly their
 // flash memory). The 150MHz tag sets the CPU clock to 150 MHz and the flash
 // clock to very forgiving 75 MHz. The 200MHz tag overclocks the CPU and most
-// peripherals to 200 MHz, the flash clock is 100 MHz.
+// peripherals to 200 MHz, the flash clock is 100 MHz. The 300MHz tag raises
+// the core voltage to 1.20 V and overclocks the CPU to 300 MHz, the flash clock
+// is 100 MHz.
 //
 // The flash clock is also used for the PSRAM if your board has one. Keep in
 // mind that most common PSRAM chips support up 100 MHz clock.
 //
+// The system timer can also be selected using build tags. The timerst tag
+// selects the tickless timer based on the TIMER0 alarm 0 (see the
+// hal/system/timer/timerst package). The systick tag selects the CPU0 SysTick
+// ticking every millisecond (see the hal/system/timer/systick package). Both
+// leave the RISCV platform timer (MTIME) unused.
+//
 // All peripheral drivers from the hal directory import this package to ensure
 // proper system initialization. You can avoid any effects of importing this
 // package by setting the nosysinit build flag.
@@ -0,0 +1,17 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nosysinit && systick && !timerst
+
+package init
+
+import (
+	"time"
+
+	"github.com/embeddedgo/pico/hal/system/timer/systick"
+)
+
+func setupSystemTimer() {
+	systick.Setup(time.Millisecond)
+}
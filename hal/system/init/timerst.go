@@ -0,0 +1,13 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !nosysinit && timerst
+
+package init
+
+import "github.com/embeddedgo/pico/hal/system/timer/timerst"
+
+func setupSystemTimer() {
+	timerst.Setup()
+}
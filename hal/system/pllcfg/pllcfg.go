@@ -0,0 +1,99 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pllcfg calculates the configuration of the RP2350 PLLs. It doesn't
+// access the hardware so it can be used (and tested) on the host.
+package pllcfg
+
+// A PLL configuration.
+//
+//	vcoHz = refHz / RefDiv * FbDiv
+//	outHz = vcoHz / (PostDiv1 * PostDiv2)
+//
+// Constraints:
+//
+//	1 <= RefDiv <= 63
+//	16 <= FbDiv <= 320
+//	1 <= PostDiv <= 7
+//	refHz / RefDiv >= 5 MHz
+//	750 MHz <= vcoHz <= 1600 MHz
+type PLL struct {
+	RefDiv   int
+	FbDiv    int
+	PostDiv1 int
+	PostDiv2 int
+}
+
+// Fout calculates the output frequency of the PLL worknig with the pll
+// configuration and the refHz frequency as an input. It returns outHz < 0 if
+// the refHz is invalid or the pll configuration is invalid for the given refHz.
+func (pll PLL) Fout(refHz int64) (outHz int64) {
+	if pll.RefDiv < 1 || 63 < pll.RefDiv {
+		return -1
+	}
+	if pll.FbDiv < 16 || 320 < pll.FbDiv {
+		return -1
+	}
+	if pll.PostDiv1 < 1 || 7 < pll.PostDiv1 {
+		return -1
+	}
+	if pll.PostDiv2 < 1 || 7 < pll.PostDiv2 {
+		return -1
+	}
+	if uint64(refHz) > 100e6 {
+		return -1
+	}
+	frefHz := int(refHz) / pll.RefDiv
+	if frefHz < 5e6 {
+		return -1
+	}
+	vcoHz := frefHz * pll.FbDiv
+	if vcoHz < 750e6 || 1600e6 < vcoHz {
+		return -1
+	}
+	return int64(vcoHz / (pll.PostDiv1 * pll.PostDiv2))
+}
+
+// Find searches for the PLL configuration that generates the frequency
+// closest to targetHz from the refHz reference. If there are several
+// configurations that generate the same frequency, Find selects the one with
+// the highest VCO frequency (lowest jitter) or, if lowPower is true, the one
+// with the lowest VCO frequency (lowest power consumption). It returns the
+// found configuration and its frequency error (pll.Fout(refHz) - targetHz). It
+// returns PLL{} if refHz is invalid.
+func Find(refHz, targetHz int64, lowPower bool) (pll PLL, errHz int64) {
+	var bestErr, bestVCO int64 = -1, 0
+	for refDiv := 1; refDiv <= 63; refDiv++ {
+		frefHz := refHz / int64(refDiv)
+		if frefHz < 5e6 {
+			break
+		}
+		for pd1 := 7; pd1 >= 1; pd1-- {
+			for pd2 := pd1; pd2 >= 1; pd2-- {
+				pd := int64(pd1 * pd2)
+				fbDiv := (targetHz*pd + frefHz/2) / frefHz
+				fbDiv = max(fbDiv, (750e6+frefHz-1)/frefHz, 16)
+				fbDiv = min(fbDiv, 1600e6/frefHz, 320)
+				cfg := PLL{refDiv, int(fbDiv), pd1, pd2}
+				outHz := cfg.Fout(refHz)
+				if outHz < 0 {
+					continue
+				}
+				e := outHz - targetHz
+				if e < 0 {
+					e = -e
+				}
+				vco := outHz * pd
+				if bestErr < 0 || e < bestErr || e == bestErr &&
+					(!lowPower && vco > bestVCO || lowPower && vco < bestVCO) {
+					pll, bestErr, bestVCO = cfg, e, vco
+				}
+			}
+		}
+	}
+	if bestErr < 0 {
+		return PLL{}, 0
+	}
+	return pll, pll.Fout(refHz) - targetHz
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pllcfg
+
+import "testing"
+
+const xoscHz = 12e6
+
+func vco(refHz int64, pll PLL) int64 {
+	return refHz / int64(pll.RefDiv) * int64(pll.FbDiv)
+}
+
+func TestFindPresets(t *testing.T) {
+	// The hand-written system.SetupPico2_*MHz configurations.
+	presets := []struct {
+		hz  int64
+		pll PLL
+	}{
+		{125e6, PLL{1, 125, 6, 2}},
+		{150e6, PLL{1, 125, 5, 2}},
+		{200e6, PLL{1, 100, 3, 2}},
+		{250e6, PLL{1, 125, 3, 2}},
+		{300e6, PLL{1, 125, 5, 1}},
+	}
+	for _, p := range presets {
+		if hz := p.pll.Fout(xoscHz); hz != p.hz {
+			t.Fatalf("%v.Fout: got %d, want %d", p.pll, hz, p.hz)
+		}
+		pll, errHz := Find(xoscHz, p.hz, false)
+		if errHz != 0 {
+			t.Errorf("%d Hz: %v, error %d Hz", p.hz, pll, errHz)
+			continue
+		}
+		if hz := pll.Fout(xoscHz); hz != p.hz {
+			t.Errorf("%d Hz: %v.Fout = %d", p.hz, pll, hz)
+		}
+		// The presets use the highest possible VCO frequency.
+		if v, want := vco(xoscHz, pll), vco(xoscHz, p.pll); v != want {
+			t.Errorf("%d Hz: %v: VCO %d Hz, want %d Hz", p.hz, pll, v, want)
+		}
+	}
+}
+
+func TestFindBounds(t *testing.T) {
+	for target := int64(1e6); target <= 500e6; target += 1234567 {
+		pll, errHz := Find(xoscHz, target, target&1 != 0)
+		hz := pll.Fout(xoscHz)
+		if hz <= 0 {
+			t.Fatalf("%d Hz: invalid %v", target, pll)
+		}
+		if hz-target != errHz {
+			t.Errorf("%d Hz: %v: error %d Hz, want %d Hz", target, pll, errHz, hz-target)
+		}
+		if v := vco(xoscHz, pll); v < 750e6 || v > 1600e6 {
+			t.Errorf("%d Hz: %v: VCO %d Hz out of range", target, pll, v)
+		}
+	}
+	// The lowest and the highest possible output frequencies.
+	if pll, _ := Find(xoscHz, 0, false); pll.Fout(xoscHz) != int64(750e6)/49 {
+		t.Errorf("min: %v: %d Hz", pll, pll.Fout(xoscHz))
+	}
+	if pll, _ := Find(xoscHz, 10e9, false); pll.Fout(xoscHz) != 1596e6 {
+		t.Errorf("max: %v: %d Hz", pll, pll.Fout(xoscHz))
+	}
+}
+
+func TestFindLowPower(t *testing.T) {
+	for _, target := range []int64{125e6, 150e6, 300e6} {
+		hi, errHi := Find(xoscHz, target, false)
+		lo, errLo := Find(xoscHz, target, true)
+		if errHi != 0 || errLo != 0 {
+			t.Errorf("%d Hz: errors %d, %d Hz", target, errHi, errLo)
+			continue
+		}
+		vhi, vlo := vco(xoscHz, hi), vco(xoscHz, lo)
+		if vlo >= vhi {
+			t.Errorf("%d Hz: lowPower VCO %d Hz >= %d Hz", target, vlo, vhi)
+		}
+	}
+	// There are many exact configurations for 125 MHz, the lowest VCO is 750
+	// MHz (6 MHz * 125 / 6).
+	if lo, _ := Find(xoscHz, 125e6, true); vco(xoscHz, lo) != 750e6 {
+		t.Errorf("125 MHz: lowPower %v: VCO %d Hz", lo, vco(xoscHz, lo))
+	}
+}
+
+func TestFindBadRef(t *testing.T) {
+	for _, refHz := range []int64{-12e6, 0, 4e6, 101e6} {
+		pll, errHz := Find(refHz, 125e6, false)
+		if pll != (PLL{}) || errHz != 0 {
+			t.Errorf("%d Hz ref: got %v, %d", refHz, pll, errHz)
+		}
+		if hz := pll.Fout(refHz); hz >= 0 {
+			t.Errorf("%d Hz ref: %v.Fout = %d", refHz, pll, hz)
+		}
+	}
+}
@@ -12,6 +12,8 @@
 #define NVIC_IPR0 0xE000E400
 #define RP2350_RAMEND (0x20000000 + 520*1024)
 #define SIO_CPUID_ADDR 0xD0000000
+#define SCB_SCR 0xE000ED10
+#define SCR_SLEEPDEEP (1<<2)
 #define SIO_DOORBELL_OUT_SET 0xD0000180
 #define SIO_DOORBELL_IN_CLR 0xD000018C
 #define SIO_FIFO_ADDR 0xD0000050
@@ -177,6 +179,14 @@ TEXT IRQ26_Handler(SB),NOSPLIT|NOFRAME,$0-0
 	MOVW  $1, R1
 	MOVW  R1, (R0)  // clear this IRQ
 
+	MOVW  $·deepSleep(SB), R0
+	MOVW  (R0), R1
+	MOVW  $SCB_SCR, R0
+	MOVW  (R0), R2
+	BIC   $SCR_SLEEPDEEP, R2
+	ORR   R1, R2
+	MOVW  R2, (R0)  // update SLEEPDEEP (see SetDeepSleep)
+
 	MOVW  $ICSR_ADDR, R0
 	MOVW  $ICSR_PENDSVSET, R1
 	MOVW  R1, (R0)  // rise PendSV
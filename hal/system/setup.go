@@ -10,9 +10,13 @@ package system
 
 import (
 	"embedded/rtos"
+	"errors"
 	"runtime"
 
 	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/powman"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/hal/system/pllcfg"
 	"github.com/embeddedgo/pico/p/clocks"
 	"github.com/embeddedgo/pico/p/pll"
 	"github.com/embeddedgo/pico/p/qmi"
@@ -62,53 +66,108 @@ func SetupPico2_250MHz() {
 	Setup(12e6, PLL{1, 125, 3, 2}, PLL{1, 100, 5, 5}, 133e6)
 }
 
-// A PLL configuration.
-//
-//	vcoHz = refHz / RefDiv * FbDiv
-//	outHz = vcoHz / (PostDiv1 * PostDiv2)
-//
-// Constraints:
-//
-//	1 <= RefDiv <= 63
-//	16 <= FbDiv <= 320
-//	1 <= PostDiv <= 7
-//	refHz / RefDiv >= 5 MHz
-//	750 MHz <= vcoHz <= 1600 MHz
-type PLL struct {
-	RefDiv   int
-	FbDiv    int
-	PostDiv1 int
-	PostDiv2 int
+// SetupPico2_300MHz is like SetupPico2_250MHz but the CPU and most peripherals
+// are overclocked to 300 MHz. The QMI (flash, PSRAM) runs at 100 MHz. The core
+// voltage is raised to 1.20 V before the PLL_SYS is reprogrammed. It's far
+// beyond the spec and may not work with all chips.
+func SetupPico2_300MHz() {
+	SetCoreVoltage(1200)
+	Setup(12e6, PLL{1, 125, 5, 1}, PLL{1, 100, 5, 5}, 133e6)
 }
 
-// Fout calculates the output frequency of the PLL worknig with the pll
-// configuration and the refHz frequency as an input. It returns outHz < 0 if
-// the refHz is invalid or the pll configuration is invalid for the given refHz.
-func (pll PLL) Fout(refHz int64) (outHz int64) {
-	if pll.RefDiv < 1 || 63 < pll.RefDiv {
-		return -1
+// vmax contains the maximum PLL_SYS frequencies allowed for the core voltages.
+var vmax = [...]struct {
+	mv int
+	hz int64
+}{
+	{1100, 250e6}, // the default core voltage
+	{1150, 275e6},
+	{1200, 300e6},
+	{1250, 325e6},
+	{1300, 350e6},
+}
+
+// MaxSysFreq returns the maximum system clock frequency allowed by Setup for the
+// core voltage mv (see SetCoreVoltage). It returns 0 for voltages below 1.10 V
+// which are not characterized.
+func MaxSysFreq(mv int) int64 {
+	var hz int64
+	for _, v := range vmax {
+		if v.mv <= mv {
+			hz = v.hz
+		}
 	}
-	if pll.FbDiv < 16 || 320 < pll.FbDiv {
-		return -1
+	return hz
+}
+
+// SetCoreVoltage sets the core voltage to mv millivolts and waits until the
+// voltage regulator reports the output in regulation. Use it before calling
+// Setup to raise the voltage for higher system clock frequencies (see
+// MaxSysFreq). Voltages above 1.30 V are refused.
+func SetCoreVoltage(mv int) {
+	if mv > powman.MaxLimitedVoltage {
+		panic("core voltage too high")
 	}
-	if pll.PostDiv1 < 1 || 7 < pll.PostDiv1 {
-		return -1
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	powman.SetVoltage(mv)
+	// Give the core supply some time to settle (pico-sdk waits 1 ms here).
+	hz := clock.SYS.Freq()
+	if hz <= 0 {
+		hz = 150e6 // not set up yet, assume the maximum boot frequency
 	}
-	if pll.PostDiv2 < 1 || 7 < pll.PostDiv2 {
-		return -1
+	internal.BusyWaitAtLeastCycles(uint(hz) / 1000)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// PLL is a PLL configuration (see pllcfg.PLL).
+type PLL = pllcfg.PLL
+
+// FindPLL searches for the PLL configuration that generates the frequency
+// closest to targetHz from the refHz reference (see pllcfg.Find).
+func FindPLL(refHz, targetHz int64, lowPower bool) (pll PLL, errHz int64) {
+	return pllcfg.Find(refHz, targetHz, lowPower)
+}
+
+// ErrFreqTooHigh is returned by SetupPico2 if the requested frequency exceeds
+// the highest one allowed by MaxSysFreq.
+var ErrFreqTooHigh = errors.New("system: frequency too high")
+
+// SetupPico2 setups the system assuming it is an RPI Pico 2 compatible (see
+// SetupPico2_125MHz) with the CPU clock as close as possible to targetHz. The
+// QSPI flash clock doesn't exceed 133 MHz. If targetHz requires a higher core
+// voltage (see MaxSysFreq) SetupPico2 raises it. It returns the actual CPU
+// clock frequency or ErrFreqTooHigh if it exceeds the maximum frequency allowed
+// for the MaxLimitedVoltage core voltage. The system isn't modified in case
+// of error.
+func SetupPico2(targetHz int64) (sysHz int64, err error) {
+	const xoscHz = 12e6
+	sys, _ := FindPLL(xoscHz, targetHz, false)
+	sysHz = sys.Fout(xoscHz)
+	if sysHz < 0 {
+		panic("can't find PLL_SYS cfg")
 	}
-	if uint64(refHz) > 100e6 {
-		return -1
+	mv := 0
+	for _, v := range vmax {
+		if v.hz >= sysHz {
+			mv = v.mv
+			break
+		}
 	}
-	frefHz := int(refHz) / pll.RefDiv
-	if frefHz < 5e6 {
-		return -1
+	if mv == 0 {
+		return 0, ErrFreqTooHigh
 	}
-	vcoHz := frefHz * pll.FbDiv
-	if vcoHz < 750e6 || 1600e6 < vcoHz {
-		return -1
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	cur := powman.Voltage()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	if mv > cur {
+		SetCoreVoltage(mv)
 	}
-	return int64(vcoHz / (pll.PostDiv1 * pll.PostDiv2))
+	Setup(xoscHz, sys, PLL{1, 100, 5, 5}, 133e6)
+	return sysHz, nil
 }
 
 /*
@@ -140,6 +199,11 @@ func (pll PLL) Fout(refHz int64) (outHz int64) {
 // as the source of the reference frequency to both PLLs and an QSPI flash
 // supporting maxFlashHz clock from which the code is executed (XIP).
 func Setup(xoscHz int64, sys, usb PLL, maxFlashHz int64) {
+	setupArgs.xoscHz = xoscHz
+	setupArgs.sys = sys
+	setupArgs.usb = usb
+	setupArgs.maxFlashHz = maxFlashHz
+
 	// The default configuration in the ACCESSCTRL makes some of the registers
 	// used below only accessible in the priviledged mode.
 	runtime.LockOSThread()
@@ -178,7 +242,50 @@ func Setup(xoscHz int64, sys, usb PLL, maxFlashHz int64) {
 
 	// 00500 PICO_RUNTIME_INIT_CLOCKS
 
-	// Disable resus that may be enabled from previous software.
+	sysHz := setupClocks(xoscHz, sys, usb)
+
+	// pico-sdk starts all tick generators here, all configurrd to 1 MHz. We
+	// leave them disabled and enable one by one if needed.
+
+	// 00600 PICO_RUNTIME_INIT_POST_CLOCK_RESETS
+
+	// Remove reset from all peripherals
+	internal.AtomicClear(&rst.RESET, allp)
+	for rst.RESET_DONE.LoadBits(allp) != allp {
+	}
+
+	setupFlash(sysHz, maxFlashHz)
+
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// setupArgs stores the arguments of the last Setup call.
+var setupArgs struct {
+	xoscHz     int64
+	sys, usb   PLL
+	maxFlashHz int64
+}
+
+// RestoreClocks restores the clock configuration set by the last call of the
+// Setup function, without resetting any peripheral. Use it when the clocks
+// were changed, e.g. after waking up from the dormant mode. The clock notifiers
+// registered using clock.AddNotifier are informed about the restored SYS and
+// PERI frequencies.
+func RestoreClocks() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	a := &setupArgs
+	sysHz := setupClocks(a.xoscHz, a.sys, a.usb)
+	setupFlash(sysHz, a.maxFlashHz)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	clocksRestored()
+}
+
+func setupClocks(xoscHz int64, sys, usb PLL) (sysHz int64) {
+	// Disable resus that may be enabled from previous software (see also
+	// hal/resus).
 	clk := clocks.CLOCKS()
 	clk.SYS_RESUS_CTRL.Store(0)
 
@@ -211,10 +318,13 @@ func Setup(xoscHz int64, sys, usb PLL, maxFlashHz int64) {
 	}
 
 	// Setup PLLs.
-	sysHz := sys.Fout(xoscHz)
+	sysHz = sys.Fout(xoscHz)
 	if sysHz < 0 {
 		panic("bad PLL_SYS cfg")
 	}
+	if sysHz > MaxSysFreq(powman.Voltage()) {
+		panic("core voltage too low for PLL_SYS freq")
+	}
 	setupPLL(pll.SYS(), resets.PLL_SYS, sys)
 	usbHz := usb.Fout(xoscHz)
 	if usbHz < 0 {
@@ -253,17 +363,10 @@ func Setup(xoscHz int64, sys, usb PLL, maxFlashHz int64) {
 		0, clocks.ADC_CLKSRC_PLL_USB,
 		uint(usbHz), 1<<clocks.ADC_INTn,
 	)
+	return sysHz
+}
 
-	// pico-sdk starts all tick generators here, all configurrd to 1 MHz. We
-	// leave them disabled and enable one by one if needed.
-
-	// 00600 PICO_RUNTIME_INIT_POST_CLOCK_RESETS
-
-	// Remove reset from all peripherals
-	internal.AtomicClear(&rst.RESET, allp)
-	for rst.RESET_DONE.LoadBits(allp) != allp {
-	}
-
+func setupFlash(sysHz, maxFlashHz int64) {
 	// Increase the QSPI Flash clock speed.
 	qmiDiv := (uint(sysHz)-1)/uint(maxFlashHz) + 1
 	qmi.QMI().M[0].TIMING.StoreBits(
@@ -271,9 +374,6 @@ func Setup(xoscHz int64, sys, usb PLL, maxFlashHz int64) {
 		qmi.TIMING(qmiDiv)<<qmi.CLKDIVn| // SCK = freq(SYS) / CLKDIV
 			qmi.TIMING(qmiDiv)<<qmi.RXDELAYn, // RXDELAY unit is period(SYS)/2
 	)
-
-	rtos.SetPrivLevel(pl)
-	runtime.UnlockOSThread()
 }
 
 func setupPLL(p *pll.Periph, reset uint32, cfg PLL) {
@@ -307,3 +407,5 @@ func setupPLL(p *pll.Periph, reset uint32, cfg PLL) {
 }
 
 func setClock(clk int, src, auxsrc clocks.CTRL, freqHz uint, div clocks.DIV)
+
+func clocksRestored()
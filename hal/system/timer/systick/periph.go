@@ -0,0 +1,40 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package systick
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// scsRegs contains the used SysTick and SCB registers of the Cortex-M33 System
+// Control Space.
+type scsRegs struct {
+	_ structs.HostLayout
+
+	_        [4]uint32
+	SYST_CSR mmio.U32
+	SYST_RVR mmio.U32
+	SYST_CVR mmio.U32
+	_        [826]uint32
+	ICSR     mmio.U32
+	_        [6]uint32
+	SHPR3    mmio.U32
+}
+
+const (
+	csrEnable     = 1 << 0     // SYST_CSR.ENABLE
+	csrTickInt    = 1 << 1     // SYST_CSR.TICKINT
+	icsrPendSVSet = 1 << 28    // ICSR.PENDSVSET
+	shprPri15     = 0xff << 24 // SHPR3.PRI_15 (SysTick priority)
+	shprPri15n    = 24
+)
+
+func scs() *scsRegs {
+	return (*scsRegs)(unsafe.Pointer(mmap.PPB_BASE + 0xe000))
+}
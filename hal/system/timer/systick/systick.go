@@ -0,0 +1,85 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package systick provides the implementation of a system timer based on the
+// Cortex-M SysTick timer of the CPU0.
+//
+// Unlike the other system timers this one isn't tickless. SysTick generates an
+// interrupt every period and the system time is simply the number of elapsed
+// periods so its resolution is equal to the period. The SysTick is clocked
+// from the 1 MHz CPU0 tick, independently of the CPU clock.
+package systick
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync/atomic"
+	"time"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/p/sio"
+	"github.com/embeddedgo/pico/p/ticks"
+)
+
+var (
+	period int64 // ns
+	count  atomic.Int64
+	alarm  atomic.Int64
+)
+
+// Setup configures and sets the SysTick as the Embedded Go system timer. The
+// period must be in the range from 1 µs to 16.7 s (1 ms is a typical value).
+// Setup must be called by the CPU0.
+func Setup(p time.Duration) {
+	us := p.Microseconds()
+	if us < 1 || us > 1<<24 {
+		panic("systick: bad period")
+	}
+	period = us * 1e3
+	alarm.Store(-1)
+
+	runtime.LockOSThread()
+	if sio.SIO().CPUID.Load() != 0 {
+		panic("systick: not on CPU0")
+	}
+	pl, _ := rtos.SetPrivLevel(0)
+
+	t := &ticks.TICKS().T[ticks.PROC0]
+	t.CYCLES.Store(uint32(clock.REF.Freq()) / 1e6)
+	t.CTRL.Store(ticks.ENABLE)
+
+	r := scs()
+	r.SYST_CSR.Store(0)
+	r.SYST_RVR.Store(uint32(us - 1))
+	r.SYST_CVR.Store(0)
+	r.SHPR3.StoreBits(shprPri15, (255-rtos.IntPrioSysTimer)<<shprPri15n)
+	// CLKSOURCE=0 selects the CPU tick.
+	r.SYST_CSR.Store(csrEnable | csrTickInt)
+
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+
+	rtos.SetSystemTimer(nanotime, setAlarm)
+}
+
+//go:nosplit
+func nanotime() int64 {
+	return count.Load() * period
+}
+
+//go:nosplit
+func setAlarm(ns int64) {
+	alarm.Store(ns)
+}
+
+//go:interrupthandler
+func _SysTick_Handler() {
+	now := count.Add(1) * period
+	if a := alarm.Load(); a >= 0 && a <= now {
+		scs().ICSR.Store(icsrPendSVSet)
+	}
+}
+
+//go:linkname _SysTick_Handler SysTick_Handler
@@ -0,0 +1,28 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+#define ICSR_ADDR 0xe000ed04
+#define ICSR_PENDSVSET (1<<28)
+
+#define TIMER0_INTR_ADDR (0x400b0000 + 0x3c)
+#define TIMER0_INTF_ADDR (0x400b0000 + 0x44)
+
+// TIMER0_IRQ_0 handler
+TEXT IRQ0_Handler(SB),NOSPLIT|NOFRAME,$0-0
+	// Set PendSV bit first to avoid DSB but ensure exception tail-chaining
+	MOVW  $ICSR_ADDR, R0
+	MOVW  $ICSR_PENDSVSET, R1
+	MOVW  R1, (R0)
+
+	// Clear this IRQ (the forced one too).
+	MOVW  $TIMER0_INTF_ADDR, R0
+	MOVW  $0, R1
+	MOVW  R1, (R0)
+	MOVW  $TIMER0_INTR_ADDR, R0
+	MOVW  $1, R1
+	MOVW  R1, (R0)
+
+	RET
@@ -0,0 +1,98 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timerst provides the implementation of a tickless system timer based
+// on the TIMER0 peripheral. It uses the alarm 0 so the TIMER0 cannot be used
+// with the hal/timer/timer0 package at the same time (use TIMER1 instead).
+package timerst
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"runtime"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/resets"
+	"github.com/embeddedgo/pico/p/ticks"
+)
+
+// The hal/timer package cannot be imported here because it imports the
+// hal/system/init package.
+type periph struct {
+	_        structs.HostLayout
+	_        [4]mmio.U32
+	ALARM    [4]mmio.U32
+	ARMED    mmio.U32
+	TIMERAWH mmio.U32
+	TIMERAWL mmio.U32
+	_        [4]mmio.U32
+	INTR     mmio.U32
+	INTE     mmio.U32
+	INTF     mmio.U32
+}
+
+//go:nosplit
+func timer0() *periph {
+	return (*periph)(unsafe.Pointer(mmap.TIMER0_BASE))
+}
+
+// Setup configures and sets the TIMER0 as the Embedded Go system timer. The
+// timer resolution is 1 uS (true for any integer MHz crystal).
+func Setup() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+
+	t := &ticks.TICKS().T[ticks.TIMER0]
+	t.CYCLES.Store(uint32(clock.REF.Freq()) / 1e6)
+	t.CTRL.Store(ticks.ENABLE)
+
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+
+	internal.SetReset(resets.TIMER0, true)
+	internal.SetReset(resets.TIMER0, false)
+	p := timer0()
+	p.INTE.Store(1)
+	irq.TIMER0_0.Enable(rtos.IntPrioSysTimer, 0)
+	irq.TIMER0_0.Enable(rtos.IntPrioSysTimer, 1)
+
+	rtos.SetSystemTimer(nanotime, setAlarm)
+}
+
+//go:nosplit
+func nanotime() int64 {
+	p := timer0()
+	ph := p.TIMERAWH.Load()
+	l := p.TIMERAWL.Load()
+	h := p.TIMERAWH.Load()
+	if h != ph {
+		l = 0
+	}
+	return (int64(h)<<32 + int64(l)) * 1e3
+}
+
+// setAlarm sets the alarm 0 to the lower 32 bits of the alarm time. An alarm
+// set more than 2^32 µs ahead fires early which is harmless because the
+// scheduler checks the time anyway and sets the alarm again.
+//
+//go:nosplit
+func setAlarm(ns int64) {
+	p := timer0()
+	p.ARMED.Store(1) // disarm
+	if ns < 0 {
+		return
+	}
+	us := ns / 1e3
+	p.ALARM[0].Store(uint32(us))
+	if nanotime()/1e3 >= us && p.ARMED.Load()&1 != 0 {
+		// The alarm time has passed before arming. Force the interrupt.
+		p.ARMED.Store(1)
+		p.INTF.Store(1)
+	}
+}
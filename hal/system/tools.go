@@ -7,6 +7,8 @@ package system
 import (
 	"embedded/rtos"
 	"sync/atomic"
+
+	"github.com/embeddedgo/pico/p/sio"
 )
 
 const ncpus = 2 // NextCPU requires ncpus to be a power of 2
@@ -19,3 +21,23 @@ var cpu uint32
 func NextCPU() rtos.IntCtx {
 	return rtos.IntCtx(atomic.AddUint32(&cpu, 1) & (ncpus - 1))
 }
+
+// deepSleep is the value of the SLEEPDEEP bit in the System Control Register
+// set by the SIO_IRQ_BELL handler (see rt0.s).
+var deepSleep uint32
+
+// SetDeepSleep sets the SLEEPDEEP bit on both CPUs. If set, the CPU enters the
+// deep sleep state when idle. The system enters the sleep state, in which the
+// clocks are gated according to the CLOCKS SLEEP_EN0 and SLEEP_EN1 registers,
+// when both CPUs are in deep sleep.
+func SetDeepSleep(enable bool) {
+	var v uint32
+	if enable {
+		v = 1 << 2
+	}
+	atomic.StoreUint32(&deepSleep, v)
+	// The SIO_IRQ_BELL handler copies deepSleep to the SCR.
+	SIO := sio.SIO()
+	SIO.DOORBELL_IN_SET.Store(1)
+	SIO.DOORBELL_OUT_SET.Store(1)
+}
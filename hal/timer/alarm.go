@@ -0,0 +1,145 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"embedded/rtos"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+)
+
+// An Alarm runs a callback at the specified time. The callback is called by
+// the interrupt handler so it and all functions it calls should have the
+// go:nosplit directive (go:nowritebarrierrec is also recommended).
+type Alarm struct {
+	t      *Timer
+	n      uint8
+	target uint64
+	period uint64
+	fn     unsafe.Pointer // func()
+	note   rtos.Note
+}
+
+// Timer returns the timer the alarm belongs to.
+func (a *Alarm) Timer() *Timer {
+	return a.t
+}
+
+// Num returns the alarm number (0..3).
+func (a *Alarm) Num() int {
+	return int(a.n)
+}
+
+// Free cancels the alarm and returns it to the pool of free alarms.
+func (a *Alarm) Free() {
+	a.Cancel()
+	t := a.t
+	t.mx.Lock()
+	t.free |= 1 << a.n
+	t.mx.Unlock()
+}
+
+func (a *Alarm) setFn(fn func()) {
+	var h unsafe.Pointer
+	if fn != nil {
+		h = *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+	}
+	atomic.StorePointer(&a.fn, h)
+}
+
+// Set sets the alarm to call fn once when the counter reaches the value at.
+// If at is in the past fn is called as soon as possible.
+func (a *Alarm) Set(at uint64, fn func()) {
+	a.Cancel()
+	a.target = at
+	a.period = 0
+	a.setFn(fn)
+	a.arm()
+}
+
+// SetAfter works like Set but takes the duration relative to now.
+func (a *Alarm) SetAfter(d time.Duration, fn func()) {
+	a.Set(a.t.Now()+uint64(d.Microseconds()), fn)
+}
+
+// SetPeriodic sets the alarm to call fn periodically, the first time after one
+// period. If the ISR is late the missed calls are skipped.
+func (a *Alarm) SetPeriodic(period time.Duration, fn func()) {
+	us := period.Microseconds()
+	if us <= 0 {
+		panic("timer: bad period")
+	}
+	a.Cancel()
+	a.period = uint64(us)
+	a.target = a.t.Now() + a.period
+	a.setFn(fn)
+	a.arm()
+}
+
+// Cancel cancels the alarm.
+func (a *Alarm) Cancel() {
+	p := a.t.p
+	mask := uint32(1) << a.n
+	internal.AtomicClearU32(&p.INTE, mask)
+	p.ARMED.Store(mask) // write 1 to disarm
+	atomic.StorePointer(&a.fn, nil)
+	internal.AtomicClearU32(&p.INTF, mask)
+	p.INTR.Store(mask)
+	internal.AtomicSetU32(&p.INTE, mask)
+}
+
+// arm arms the alarm hardware for a.target. The ALARM register is compared
+// with the lower 32 bits of the counter so the alarm that is more than 2^32 µs
+// ahead fires early and is rearmed by the ISR.
+//
+//go:nosplit
+func (a *Alarm) arm() {
+	t := a.t
+	p := t.p
+	mask := uint32(1) << a.n
+	p.ALARM[a.n].Store(uint32(a.target))
+	if int64(t.Now()-a.target) >= 0 && p.ARMED.Load()&mask != 0 {
+		// Missed. Disarm and force the interrupt.
+		p.ARMED.Store(mask)
+		internal.AtomicSetU32(&p.INTF, mask)
+	}
+}
+
+//go:nosplit
+func (a *Alarm) isr() {
+	h := atomic.LoadPointer(&a.fn)
+	if h == nil {
+		return // canceled
+	}
+	now := a.t.Now()
+	if int64(now-a.target) < 0 {
+		a.arm() // far target, see arm
+		return
+	}
+	if a.period != 0 {
+		a.target += a.period
+		if int64(now-a.target) >= 0 {
+			a.target += (now - a.target + a.period) / a.period * a.period
+		}
+		a.arm()
+	}
+	(*(*func())(unsafe.Pointer(&h)))()
+}
+
+// wakeup is used by sleepUntil as the alarm callback.
+//
+//go:nosplit
+func (a *Alarm) wakeup() {
+	a.note.Wakeup()
+}
+
+func (a *Alarm) sleepUntil(us uint64) {
+	a.note.Clear()
+	a.Set(us, a.wakeup)
+	a.note.Sleep(-1)
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timer
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/resets"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	TIMEHW   mmio.U32
+	TIMELW   mmio.U32
+	TIMEHR   mmio.U32
+	TIMELR   mmio.U32
+	ALARM    [4]mmio.U32
+	ARMED    mmio.U32
+	TIMERAWH mmio.U32
+	TIMERAWL mmio.U32
+	DBGPAUSE mmio.U32
+	PAUSE    mmio.U32
+	LOCKED   mmio.U32
+	SOURCE   mmio.U32
+	INTR     mmio.U32
+	INTE     mmio.U32
+	INTF     mmio.U32
+	INTS     mmio.U32
+}
+
+// TIMER returns the n-th timer peripheral (TIMER0 or TIMER1).
+func TIMER(n int) *Periph {
+	if uint(n) > 1 {
+		panic("wrong timer number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.TIMER0_BASE + uintptr(n)*0x8000))
+}
+
+func num(p *Periph) int {
+	return int(uintptr(unsafe.Pointer(p))-mmap.TIMER0_BASE) / 0x8000
+}
+
+// SetReset allows to assert/deassert the reset signal to the timer.
+func (p *Periph) SetReset(assert bool) {
+	internal.SetReset(resets.TIMER0<<uint(num(p)), assert)
+}
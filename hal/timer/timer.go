@@ -0,0 +1,122 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timer provides a driver for the TIMER0 and TIMER1 peripherals.
+//
+// Every timer has a 64-bit counter incremented every microsecond and four
+// alarms that can run callbacks at the specified time, once or periodically.
+package timer
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/p/ticks"
+)
+
+// Timer is a driver for the timer peripheral.
+type Timer struct {
+	p      *Periph
+	mx     sync.Mutex
+	free   uint8
+	alarms [4]Alarm
+}
+
+// NewTimer returns a new driver for p.
+func NewTimer(p *Periph) *Timer {
+	t := &Timer{p: p, free: 0xf}
+	for i := range t.alarms {
+		t.alarms[i].t = t
+		t.alarms[i].n = uint8(i)
+	}
+	return t
+}
+
+// Periph returns the underlying timer peripheral.
+func (t *Timer) Periph() *Periph {
+	return t.p
+}
+
+// Setup configures the 1 MHz tick for the timer and enables its interrupts.
+// It doesn't reset the counter which counts from the power-on.
+func (t *Timer) Setup() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	tk := &ticks.TICKS().T[ticks.TIMER0+num(t.p)]
+	tk.CYCLES.Store(uint32(clock.REF.Freq()) / 1e6)
+	tk.CTRL.Store(ticks.ENABLE)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+
+	p := t.p
+	p.SOURCE.Store(0) // tick
+	p.INTE.Store(0xf)
+}
+
+// Now returns the current value of the counter (µs).
+//
+//go:nosplit
+func (t *Timer) Now() uint64 {
+	p := t.p
+	h := p.TIMERAWH.Load()
+	for {
+		l := p.TIMERAWL.Load()
+		h1 := p.TIMERAWH.Load()
+		if h1 == h {
+			return uint64(h)<<32 | uint64(l)
+		}
+		h = h1
+	}
+}
+
+// SleepUntil blocks the calling goroutine until the counter reaches the value
+// us. It uses an alarm to wake up the goroutine just before the deadline and
+// the busy waiting for the remaining time. If there is no free alarm or the
+// deadline is very close SleepUntil busy waits all the time.
+func (t *Timer) SleepUntil(us uint64) {
+	const spin = 20 // µs
+	if int64(us-t.Now()) > spin {
+		if a := t.AllocAlarm(); a != nil {
+			a.sleepUntil(us - spin)
+			a.Free()
+		}
+	}
+	for int64(us-t.Now()) > 0 {
+	}
+}
+
+// Sleep works like SleepUntil but takes the duration relative to now.
+func (t *Timer) Sleep(d time.Duration) {
+	t.SleepUntil(t.Now() + uint64(d.Microseconds()))
+}
+
+// AllocAlarm allocates a free alarm. It returns nil if there is no free alarm.
+// Use Alarm.Free to free an unused alarm.
+func (t *Timer) AllocAlarm() *Alarm {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	for i := range t.alarms {
+		if t.free&(1<<i) != 0 {
+			t.free &^= 1 << i
+			return &t.alarms[i]
+		}
+	}
+	return nil
+}
+
+// ISR is the interrupt handler for the alarm n.
+//
+//go:nosplit
+//go:nowritebarrierrec
+func (t *Timer) ISR(n int) {
+	p := t.p
+	mask := uint32(1) << uint(n)
+	internal.AtomicClearU32(&p.INTF, mask)
+	p.INTR.Store(mask) // write 1 to clear
+	t.alarms[n].isr()
+}
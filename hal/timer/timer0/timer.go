@@ -0,0 +1,49 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timer0 provides the driver for the TIMER0 peripheral. It cannot be
+// used together with the TIMER0 based system timer (the timerst build tag).
+package timer0
+
+import (
+	"embedded/rtos"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/timer"
+)
+
+var driver *timer.Timer
+
+// Timer returns the ready to use driver for the TIMER0 peripheral.
+func Timer() *timer.Timer {
+	if driver == nil {
+		driver = timer.NewTimer(timer.TIMER(0))
+		driver.Setup()
+		cpu := system.NextCPU()
+		irq.TIMER0_0.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER0_1.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER0_2.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER0_3.Enable(rtos.IntPrioLow, cpu)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _TIMER0_0_Handler() { driver.ISR(0) }
+
+//go:interrupthandler
+func _TIMER0_1_Handler() { driver.ISR(1) }
+
+//go:interrupthandler
+func _TIMER0_2_Handler() { driver.ISR(2) }
+
+//go:interrupthandler
+func _TIMER0_3_Handler() { driver.ISR(3) }
+
+//go:linkname _TIMER0_0_Handler IRQ0_Handler
+//go:linkname _TIMER0_1_Handler IRQ1_Handler
+//go:linkname _TIMER0_2_Handler IRQ2_Handler
+//go:linkname _TIMER0_3_Handler IRQ3_Handler
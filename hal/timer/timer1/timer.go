@@ -0,0 +1,48 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timer1 provides the driver for the TIMER1 peripheral.
+package timer1
+
+import (
+	"embedded/rtos"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/timer"
+)
+
+var driver *timer.Timer
+
+// Timer returns the ready to use driver for the TIMER1 peripheral.
+func Timer() *timer.Timer {
+	if driver == nil {
+		driver = timer.NewTimer(timer.TIMER(1))
+		driver.Setup()
+		cpu := system.NextCPU()
+		irq.TIMER1_0.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER1_1.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER1_2.Enable(rtos.IntPrioLow, cpu)
+		irq.TIMER1_3.Enable(rtos.IntPrioLow, cpu)
+	}
+	return driver
+}
+
+//go:interrupthandler
+func _TIMER1_0_Handler() { driver.ISR(0) }
+
+//go:interrupthandler
+func _TIMER1_1_Handler() { driver.ISR(1) }
+
+//go:interrupthandler
+func _TIMER1_2_Handler() { driver.ISR(2) }
+
+//go:interrupthandler
+func _TIMER1_3_Handler() { driver.ISR(3) }
+
+//go:linkname _TIMER1_0_Handler IRQ4_Handler
+//go:linkname _TIMER1_1_Handler IRQ5_Handler
+//go:linkname _TIMER1_2_Handler IRQ6_Handler
+//go:linkname _TIMER1_3_Handler IRQ7_Handler
@@ -0,0 +1,22 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trng
+
+// INT represents the bits of the RNG_IMR, RNG_ISR and RNG_ICR registers.
+type INT uint32
+
+const (
+	EHR_VALID    INT = 0x01 << 0 //+ 192 bits have been collected in the EHR.
+	AUTOCORR_ERR INT = 0x01 << 1 //+ Autocorrelation test failed four times in a row.
+	CRNGT_ERR    INT = 0x01 << 2 //+ Two consecutive blocks of 16 bits are equal.
+	VN_ERR       INT = 0x01 << 3 //+ 32 consecutive identical bits (Von Neumann).
+)
+
+const (
+	EHR_VALIDn    = 0
+	AUTOCORR_ERRn = 1
+	CRNGT_ERRn    = 2
+	VN_ERRn       = 3
+)
@@ -0,0 +1,43 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trng
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	_                  [64]uint32
+	RNG_IMR            mmio.R32[INT]
+	RNG_ISR            mmio.R32[INT]
+	RNG_ICR            mmio.R32[INT]
+	TRNG_CONFIG        mmio.U32
+	TRNG_VALID         mmio.U32
+	EHR_DATA           [6]mmio.U32
+	RND_SOURCE_ENABLE  mmio.U32
+	SAMPLE_CNT1        mmio.U32
+	AUTOCORR_STATISTIC mmio.U32
+	TRNG_DEBUG_CONTROL mmio.U32
+	_                  uint32
+	TRNG_SW_RESET      mmio.U32
+	_                  [28]uint32
+	RNG_DEBUG_EN_INPUT mmio.U32
+	TRNG_BUSY          mmio.U32
+	RST_BITS_COUNTER   mmio.U32
+	RNG_VERSION        mmio.U32
+	_                  [7]uint32
+	RNG_BIST_CNTR      [3]mmio.U32
+}
+
+// TRNG returns the true random number generator peripheral.
+func TRNG() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.TRNG_BASE))
+}
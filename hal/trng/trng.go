@@ -0,0 +1,140 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package trng provides a driver for the true random number generator.
+//
+// The TRNG collects 192 bits of entropy from a ring oscillator at a time. The
+// collected bits are checked by the built-in health tests: autocorrelation,
+// continuous random number generator test (CRNGT) and Von Neumann balancer
+// test. The bits that failed the tests are discarded and the failure is
+// reported as an error.
+package trng
+
+import (
+	"crypto/rand"
+	"embedded/rtos"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+)
+
+var (
+	ErrAutocorr   = errors.New("trng: autocorrelation test failed")
+	ErrCRNGT      = errors.New("trng: CRNGT failed")
+	ErrVonNeumann = errors.New("trng: Von Neumann test failed")
+	ErrTimeout    = errors.New("trng: timeout")
+)
+
+const allInts = EHR_VALID | AUTOCORR_ERR | CRNGT_ERR | VN_ERR
+
+var (
+	irqOnce sync.Once
+	mu      sync.Mutex
+	ready   rtos.Note
+	ehr     [6]uint32
+	nehr    int // number of unused bytes in ehr
+)
+
+// collect collects the next 192 random bits into ehr. The TRNG registers are
+// accessible only in the privileged mode.
+func collect() error {
+	irqOnce.Do(func() {
+		irq.TRNG.Enable(rtos.IntPrioLow, system.NextCPU())
+	})
+	p := TRNG()
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	p.RNG_ICR.Store(allInts)
+	ready.Clear()
+	p.RNG_IMR.Store(0) // unmask all interrupts
+	p.RND_SOURCE_ENABLE.Store(1)
+	rtos.SetPrivLevel(pl)
+	ok := ready.Sleep(100 * time.Millisecond)
+	rtos.SetPrivLevel(0)
+	err := check(p, ok)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return err
+}
+
+// check checks the result of the collection and, if it's valid, reads the
+// collected bits into ehr. It must be called in the privileged mode.
+func check(p *Periph, ok bool) error {
+	isr := p.RNG_ISR.Load()
+	p.RND_SOURCE_ENABLE.Store(0)
+	switch {
+	case isr&AUTOCORR_ERR != 0:
+		// The TRNG doesn't work until reset.
+		p.TRNG_SW_RESET.Store(1)
+		return ErrAutocorr
+	case isr&CRNGT_ERR != 0:
+		p.RNG_ICR.Store(allInts)
+		p.RST_BITS_COUNTER.Store(1)
+		return ErrCRNGT
+	case isr&VN_ERR != 0:
+		p.RNG_ICR.Store(allInts)
+		p.RST_BITS_COUNTER.Store(1)
+		return ErrVonNeumann
+	case !ok || isr&EHR_VALID == 0:
+		p.RNG_IMR.Store(allInts)
+		return ErrTimeout
+	}
+	for i := range ehr {
+		ehr[i] = p.EHR_DATA[i].Load()
+	}
+	p.RNG_ICR.Store(EHR_VALID)
+	nehr = len(ehr) * 4
+	return nil
+}
+
+// Read fills p with random bytes. It returns an error if the health tests
+// detected a problem with the entropy source. The bytes collected before the
+// failed test are valid so n may be greater than zero in such case.
+func Read(p []byte) (n int, err error) {
+	mu.Lock()
+	for n < len(p) {
+		if nehr == 0 {
+			if err = collect(); err != nil {
+				break
+			}
+		}
+		for nehr > 0 && n < len(p) {
+			nehr--
+			p[n] = byte(ehr[nehr>>2] >> (uint(nehr&3) * 8))
+			n++
+		}
+	}
+	mu.Unlock()
+	return
+}
+
+type reader struct{}
+
+func (reader) Read(p []byte) (n int, err error) {
+	return Read(p)
+}
+
+// Reader is a global, shared instance of the TRNG based reader.
+var Reader io.Reader = reader{}
+
+// InstallCryptoRand sets the crypto/rand.Reader to Reader so the crypto
+// packages use the hardware entropy source.
+func InstallCryptoRand() {
+	rand.Reader = Reader
+}
+
+//go:interrupthandler
+func _TRNG_Handler() {
+	p := TRNG()
+	p.RNG_IMR.Store(allInts) // mask all interrupts
+	ready.Wakeup()
+}
+
+//go:linkname _TRNG_Handler IRQ39_Handler
@@ -32,11 +32,15 @@ type Driver struct {
 	rend     uintptr
 	rerr     uint32
 	rready   rtos.Note
+
+	baud int
 }
 
 // NewDriver returns a new driver for p.
 func NewDriver(p *Periph) *Driver {
-	return &Driver{p: p, wtimeout: -1, rtimeout: -1}
+	d := &Driver{p: p, wtimeout: -1, rtimeout: -1}
+	clock.AddNotifier(d)
+	return d
 }
 
 // Periph returns the underlying UART peripheral.
@@ -90,7 +94,8 @@ func (d *Driver) Baudrate() int {
 	return int((uint(8*clock.PERI.Freq()/div) + 1) / 2)
 }
 
-// SetBaudrate sets the UART baudrate.
+// SetBaudrate sets the UART baudrate. The baudrate is recalculated
+// automatically if the PERI clock frequency changes (see clock.SetSys).
 func (d *Driver) SetBaudrate(baudrate int) (actual int) {
 	if baudrate <= 0 {
 		return -1
@@ -102,6 +107,7 @@ func (d *Driver) SetBaudrate(baudrate int) (actual int) {
 	if ibrd == 0 || ibrd > 0xffff {
 		return -1
 	}
+	d.baud = baudrate
 	d.WaitTxDone()
 	p := d.p
 	cr := p.CR.Load()
@@ -114,6 +120,13 @@ func (d *Driver) SetBaudrate(baudrate int) (actual int) {
 	return int((uint(8*periHz/div) + 1) / 2)
 }
 
+// ClockChanged implements the clock.Notifier interface.
+func (d *Driver) ClockChanged(clk clock.Clock) {
+	if clk == clock.PERI && d.baud > 0 {
+		d.SetBaudrate(d.baud)
+	}
+}
+
 // Setup resets the underlying UART peripheral and configures it according to
 // the driver needs. Next it calls the SetConfig and SetBaudrate methods with
 // the provided arguments. You still need to call EnableTx/EnabeRx.
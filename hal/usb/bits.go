@@ -0,0 +1,302 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+type ADDR_ENDP uint32
+
+const (
+	ADDRESS        ADDR_ENDP = 0x7F << 0  //+ Device address.
+	ENDPOINT       ADDR_ENDP = 0x0F << 16 //+ Endpoint number.
+	INTEP_DIR      ADDR_ENDP = 0x01 << 25 //+ Host interrupt endpoint direction (In=0, Out=1).
+	INTEP_PREAMBLE ADDR_ENDP = 0x01 << 26 //+ Interrupt EP requires preamble (LS device on FS hub).
+)
+
+const (
+	ADDRESSn        = 0
+	ENDPOINTn       = 16
+	INTEP_DIRn      = 25
+	INTEP_PREAMBLEn = 26
+)
+
+type MAIN_CTRL uint32
+
+const (
+	CONTROLLER_EN MAIN_CTRL = 0x01 << 0  //+ Enable controller.
+	HOST_NDEVICE  MAIN_CTRL = 0x01 << 1  //+ Device mode = 0, Host mode = 1.
+	PHY_ISO       MAIN_CTRL = 0x01 << 2  //+ Isolates USB PHY after controller power-up.
+	SIM_TIMING    MAIN_CTRL = 0x01 << 31 //+ Reduced timings for simulation.
+)
+
+const (
+	CONTROLLER_ENn = 0
+	HOST_NDEVICEn  = 1
+	PHY_ISOn       = 2
+	SIM_TIMINGn    = 31
+)
+
+type SIE_CTRL uint32
+
+const (
+	START_TRANS              SIE_CTRL = 0x01 << 0  //+ Host: Start transaction.
+	SEND_SETUP               SIE_CTRL = 0x01 << 1  //+ Host: Send Setup packet.
+	SEND_DATA                SIE_CTRL = 0x01 << 2  //+ Host: Send transaction (OUT from host).
+	RECEIVE_DATA             SIE_CTRL = 0x01 << 3  //+ Host: Receive transaction (IN to host).
+	STOP_TRANS               SIE_CTRL = 0x01 << 4  //+ Host: Stop transaction.
+	PREAMBLE_EN              SIE_CTRL = 0x01 << 6  //+ Host: Preable enable for LS device on FS hub.
+	SOF_SYNC                 SIE_CTRL = 0x01 << 8  //+ Host: Delay packet(s) until after SOF.
+	SOF_EN                   SIE_CTRL = 0x01 << 9  //+ Host: Enable SOF generation (for full speed bus).
+	KEEP_ALIVE_EN            SIE_CTRL = 0x01 << 10 //+ Host: Enable keep alive packet (for low speed bus).
+	VBUS_EN                  SIE_CTRL = 0x01 << 11 //+ Host: Enable VBUS.
+	RESUME                   SIE_CTRL = 0x01 << 12 //+ Device: Remote wakeup.
+	RESET_BUS                SIE_CTRL = 0x01 << 13 //+ Host: Reset bus.
+	PULLDOWN_EN              SIE_CTRL = 0x01 << 15 //+ Host: Enable pull down resistors.
+	PULLUP_EN                SIE_CTRL = 0x01 << 16 //+ Device: Enable pull up resistor.
+	RPU_OPT                  SIE_CTRL = 0x01 << 17 //+ Device: Pull-up strength (0=1K2, 1=2k3).
+	TRANSCEIVER_PD           SIE_CTRL = 0x01 << 18 //+ Power down bus transceiver.
+	EP0_STOP_ON_SHORT_PACKET SIE_CTRL = 0x01 << 19 //+ Device: Stop EP0 on a short packet.
+	DIRECT_DM                SIE_CTRL = 0x01 << 24 //+ Direct control of DM.
+	DIRECT_DP                SIE_CTRL = 0x01 << 25 //+ Direct control of DP.
+	DIRECT_EN                SIE_CTRL = 0x01 << 26 //+ Direct bus drive enable.
+	EP0_INT_NAK              SIE_CTRL = 0x01 << 27 //+ Device: Set EP_STATUS_STALL_NAK when EP0 sends a NAK.
+	EP0_INT_2BUF             SIE_CTRL = 0x01 << 28 //+ Device: Set BUFF_STATUS for every 2 buffers completed on EP0.
+	EP0_INT_1BUF             SIE_CTRL = 0x01 << 29 //+ Device: Set BUFF_STATUS for every buffer completed on EP0.
+	EP0_DOUBLE_BUF           SIE_CTRL = 0x01 << 30 //+ Device: EP0 single buffered = 0, double buffered = 1.
+	EP0_INT_STALL            SIE_CTRL = 0x01 << 31 //+ Device: Set EP_STATUS_STALL_NAK when EP0 sends a STALL.
+)
+
+const (
+	START_TRANSn              = 0
+	SEND_SETUPn               = 1
+	SEND_DATAn                = 2
+	RECEIVE_DATAn             = 3
+	STOP_TRANSn               = 4
+	PREAMBLE_ENn              = 6
+	SOF_SYNCn                 = 8
+	SOF_ENn                   = 9
+	KEEP_ALIVE_ENn            = 10
+	VBUS_ENn                  = 11
+	RESUMEn                   = 12
+	RESET_BUSn                = 13
+	PULLDOWN_ENn              = 15
+	PULLUP_ENn                = 16
+	RPU_OPTn                  = 17
+	TRANSCEIVER_PDn           = 18
+	EP0_STOP_ON_SHORT_PACKETn = 19
+	DIRECT_DMn                = 24
+	DIRECT_DPn                = 25
+	DIRECT_ENn                = 26
+	EP0_INT_NAKn              = 27
+	EP0_INT_2BUFn             = 28
+	EP0_INT_1BUFn             = 29
+	EP0_DOUBLE_BUFn           = 30
+	EP0_INT_STALLn            = 31
+)
+
+// The SIE_STATUS bits that have the same names as the SIE_CTRL or INTR bits
+// are prefixed with S_.
+
+type SIE_STATUS uint32
+
+const (
+	VBUS_DETECTED     SIE_STATUS = 0x01 << 0  //+ Device: VBUS Detected.
+	LINE_STATE        SIE_STATUS = 0x03 << 2  //+ USB bus line state.
+	SUSPENDED         SIE_STATUS = 0x01 << 4  //+ Bus in suspended state.
+	SPEED             SIE_STATUS = 0x03 << 8  //+ Host: device speed (Disconnected=0, LS=1, FS=2).
+	VBUS_OVER_CURR    SIE_STATUS = 0x01 << 10 //+ VBUS over current detected.
+	S_RESUME          SIE_STATUS = 0x01 << 11 //+ Remote resume or resume from host.
+	S_RX_SHORT_PACKET SIE_STATUS = 0x01 << 12 //+ Device or Host has received a short packet.
+	CONNECTED         SIE_STATUS = 0x01 << 16 //+ Device: connected.
+	SETUP_REC         SIE_STATUS = 0x01 << 17 //+ Device: Setup packet received.
+	S_TRANS_COMPLETE  SIE_STATUS = 0x01 << 18 //+ Transaction complete.
+	S_BUS_RESET       SIE_STATUS = 0x01 << 19 //+ Device: bus reset received.
+	S_ENDPOINT_ERROR  SIE_STATUS = 0x01 << 23 //+ An endpoint has encountered an error.
+	CRC_ERROR         SIE_STATUS = 0x01 << 24 //+ CRC Error.
+	BIT_STUFF_ERROR   SIE_STATUS = 0x01 << 25 //+ Bit Stuff Error.
+	RX_OVERFLOW       SIE_STATUS = 0x01 << 26 //+ RX overflow.
+	RX_TIMEOUT        SIE_STATUS = 0x01 << 27 //+ RX timeout.
+	NAK_REC           SIE_STATUS = 0x01 << 28 //+ Host: NAK received.
+	STALL_REC         SIE_STATUS = 0x01 << 29 //+ Host: STALL received.
+	ACK_REC           SIE_STATUS = 0x01 << 30 //+ ACK received.
+	DATA_SEQ_ERROR    SIE_STATUS = 0x01 << 31 //+ Data Sequence Error.
+)
+
+const (
+	VBUS_DETECTEDn     = 0
+	LINE_STATEn        = 2
+	SUSPENDEDn         = 4
+	SPEEDn             = 8
+	VBUS_OVER_CURRn    = 10
+	S_RESUMEn          = 11
+	S_RX_SHORT_PACKETn = 12
+	CONNECTEDn         = 16
+	SETUP_RECn         = 17
+	S_TRANS_COMPLETEn  = 18
+	S_BUS_RESETn       = 19
+	S_ENDPOINT_ERRORn  = 23
+	CRC_ERRORn         = 24
+	BIT_STUFF_ERRORn   = 25
+	RX_OVERFLOWn       = 26
+	RX_TIMEOUTn        = 27
+	NAK_RECn           = 28
+	STALL_RECn         = 29
+	ACK_RECn           = 30
+	DATA_SEQ_ERRORn    = 31
+)
+
+type USB_MUXING uint32
+
+const (
+	TO_PHY         USB_MUXING = 0x01 << 0  //+
+	TO_EXTPHY      USB_MUXING = 0x01 << 1  //+
+	TO_DIGITAL_PAD USB_MUXING = 0x01 << 2  //+
+	SOFTCON        USB_MUXING = 0x01 << 3  //+
+	USBPHY_AS_GPIO USB_MUXING = 0x01 << 4  //+ Use the DP and DM pins as GPIO pins.
+	SWAP_DPDM      USB_MUXING = 0x01 << 31 //+ Swap the USB PHY DP and DM pins.
+)
+
+const (
+	TO_PHYn         = 0
+	TO_EXTPHYn      = 1
+	TO_DIGITAL_PADn = 2
+	SOFTCONn        = 3
+	USBPHY_AS_GPIOn = 4
+	SWAP_DPDMn      = 31
+)
+
+type USB_PWR uint32
+
+const (
+	PWR_VBUS_EN                 USB_PWR = 0x01 << 0 //+
+	PWR_VBUS_EN_OVERRIDE_EN     USB_PWR = 0x01 << 1 //+
+	PWR_VBUS_DETECT             USB_PWR = 0x01 << 2 //+
+	PWR_VBUS_DETECT_OVERRIDE_EN USB_PWR = 0x01 << 3 //+
+	PWR_OVERCURR_DETECT         USB_PWR = 0x01 << 4 //+
+	PWR_OVERCURR_DETECT_EN      USB_PWR = 0x01 << 5 //+
+)
+
+const (
+	PWR_VBUS_ENn                 = 0
+	PWR_VBUS_EN_OVERRIDE_ENn     = 1
+	PWR_VBUS_DETECTn             = 2
+	PWR_VBUS_DETECT_OVERRIDE_ENn = 3
+	PWR_OVERCURR_DETECTn         = 4
+	PWR_OVERCURR_DETECT_ENn      = 5
+)
+
+type INTR uint32
+
+const (
+	HOST_CONN_DIS         INTR = 0x01 << 0  //+ Host: device connected or disconnected.
+	HOST_RESUME           INTR = 0x01 << 1  //+ Host: device wakes up the host.
+	HOST_SOF              INTR = 0x01 << 2  //+ Host: SOF sent.
+	TRANS_COMPLETE        INTR = 0x01 << 3  //+ SIE_STATUS.TRANS_COMPLETE is set.
+	BUFF_STATUS           INTR = 0x01 << 4  //+ Any bit in BUFF_STATUS is set.
+	ERROR_DATA_SEQ        INTR = 0x01 << 5  //+ SIE_STATUS.DATA_SEQ_ERROR.
+	ERROR_RX_TIMEOUT      INTR = 0x01 << 6  //+ SIE_STATUS.RX_TIMEOUT.
+	ERROR_RX_OVERFLOW     INTR = 0x01 << 7  //+ SIE_STATUS.RX_OVERFLOW.
+	ERROR_BIT_STUFF       INTR = 0x01 << 8  //+ SIE_STATUS.BIT_STUFF_ERROR.
+	ERROR_CRC             INTR = 0x01 << 9  //+ SIE_STATUS.CRC_ERROR.
+	STALL                 INTR = 0x01 << 10 //+ SIE_STATUS.STALL_REC.
+	VBUS_DETECT           INTR = 0x01 << 11 //+ SIE_STATUS.VBUS_DETECTED.
+	BUS_RESET             INTR = 0x01 << 12 //+ SIE_STATUS.BUS_RESET.
+	DEV_CONN_DIS          INTR = 0x01 << 13 //+ Device connection state changes.
+	DEV_SUSPEND           INTR = 0x01 << 14 //+ Device suspend state changes.
+	DEV_RESUME_FROM_HOST  INTR = 0x01 << 15 //+ Device receives a resume from the host.
+	SETUP_REQ             INTR = 0x01 << 16 //+ Device: SIE_STATUS.SETUP_REC.
+	DEV_SOF               INTR = 0x01 << 17 //+ Device receives a SOF.
+	ABORT_DONE            INTR = 0x01 << 18 //+ Any bit in ABORT_DONE is set.
+	EP_STALL_NAK          INTR = 0x01 << 19 //+ Any bit in EP_STATUS_STALL_NAK is set.
+	RX_SHORT_PACKET       INTR = 0x01 << 20 //+ SIE_STATUS.RX_SHORT_PACKET.
+	ENDPOINT_ERROR        INTR = 0x01 << 21 //+ SIE_STATUS.ENDPOINT_ERROR.
+	DEV_SM_WATCHDOG_FIRED INTR = 0x01 << 22 //+ DEV_SM_WATCHDOG.FIRED.
+	EPX_STOPPED_ON_NAK    INTR = 0x01 << 23 //+ NAK_POLL.EPX_STOPPED_ON_NAK.
+)
+
+const (
+	HOST_CONN_DISn         = 0
+	HOST_RESUMEn           = 1
+	HOST_SOFn              = 2
+	TRANS_COMPLETEn        = 3
+	BUFF_STATUSn           = 4
+	ERROR_DATA_SEQn        = 5
+	ERROR_RX_TIMEOUTn      = 6
+	ERROR_RX_OVERFLOWn     = 7
+	ERROR_BIT_STUFFn       = 8
+	ERROR_CRCn             = 9
+	STALLn                 = 10
+	VBUS_DETECTn           = 11
+	BUS_RESETn             = 12
+	DEV_CONN_DISn          = 13
+	DEV_SUSPENDn           = 14
+	DEV_RESUME_FROM_HOSTn  = 15
+	SETUP_REQn             = 16
+	DEV_SOFn               = 17
+	ABORT_DONEn            = 18
+	EP_STALL_NAKn          = 19
+	RX_SHORT_PACKETn       = 20
+	ENDPOINT_ERRORn        = 21
+	DEV_SM_WATCHDOG_FIREDn = 22
+	EPX_STOPPED_ON_NAKn    = 23
+)
+
+// DPRAM endpoint control register bits.
+
+type EPCTRL uint32
+
+const (
+	EP_EN         EPCTRL = 0x01 << 31 //+ Enable the endpoint.
+	EP_DOUBLE_BUF EPCTRL = 0x01 << 30 //+ Double buffered endpoint.
+	EP_INT_1BUF   EPCTRL = 0x01 << 29 //+ Set BUFF_STATUS for every buffer completed.
+	EP_INT_2BUF   EPCTRL = 0x01 << 28 //+ Set BUFF_STATUS for every 2 buffers completed.
+	EP_TYPE       EPCTRL = 0x03 << 26 //+ Endpoint type.
+	EP_CONTROL    EPCTRL = 0x00 << 26 //  Control endpoint.
+	EP_ISO        EPCTRL = 0x01 << 26 //  Isochronous endpoint.
+	EP_BULK       EPCTRL = 0x02 << 26 //  Bulk endpoint.
+	EP_INTERRUPT  EPCTRL = 0x03 << 26 //  Interrupt endpoint.
+	EP_INT_STALL  EPCTRL = 0x01 << 17 //+ Set EP_STATUS_STALL_NAK when a STALL is sent.
+	EP_INT_NAK    EPCTRL = 0x01 << 16 //+ Set EP_STATUS_STALL_NAK when a NAK is sent.
+	EP_ADDR       EPCTRL = 0xFFFF     //+ Buffer address (64 byte aligned offset in DPRAM).
+)
+
+const (
+	EP_TYPEn = 26
+)
+
+// The control registers of the host interrupt endpoints (HostDPRAM.INTCTRL) use
+// the EPCTRL layout with the polling interval in place of EP_INT_STALL and
+// EP_INT_NAK.
+const (
+	EP_INTERVAL EPCTRL = 0x3FF << 16 //+ Host: polling interval of the interrupt endpoint - 1 (ms).
+)
+
+const (
+	EP_INTERVALn = 16
+)
+
+// DPRAM buffer control register bits. The lower half controls buffer 0, the
+// upper half controls buffer 1 (use the BUF1 shift).
+
+type BUFCTRL uint32
+
+const (
+	BUF_LEN   BUFCTRL = 0x3FF << 0 //+ Buffer length.
+	BUF_AVAIL BUFCTRL = 0x01 << 10 //+ Buffer is available for the controller.
+	BUF_STALL BUFCTRL = 0x01 << 11 //+ Reply with STALL.
+	BUF_RESET BUFCTRL = 0x01 << 12 //+ Reset the buffer selector to buffer 0.
+	BUF_DATA1 BUFCTRL = 0x01 << 13 //+ DATA1 PID (DATA0 if zero).
+	BUF_LAST  BUFCTRL = 0x01 << 14 //+ The last buffer of the transfer.
+	BUF_FULL  BUFCTRL = 0x01 << 15 //+ Buffer contains data.
+)
+
+const (
+	BUF_LENn   = 0
+	BUF_AVAILn = 10
+	BUF_STALLn = 11
+	BUF_RESETn = 12
+	BUF_DATA1n = 13
+	BUF_LASTn  = 14
+	BUF_FULLn  = 15
+	BUF1       = 16
+)
@@ -0,0 +1,178 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cdc implements the USB Communications Device Class functions.
+package cdc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/usb"
+)
+
+// Class specific requests.
+const (
+	SetLineCoding       = 0x20
+	GetLineCoding       = 0x21
+	SetControlLineState = 0x22
+	SendBreak           = 0x23
+)
+
+// Control line state bits.
+const (
+	DTR = 1 << 0
+	RTS = 1 << 1
+)
+
+// ACM implements the Abstract Control Model function that is seen by the
+// host as a virtual serial port. It implements io.ReadWriter.
+type ACM struct {
+	name  string
+	dev   *usb.Device
+	notif *usb.Endpoint
+	in    *usb.Endpoint
+	out   *usb.Endpoint
+	comm  int
+
+	lineCoding [7]byte
+	lineState  uint16
+
+	wmu  sync.Mutex
+	rbuf [64]byte
+	rpos int
+	rlen int
+}
+
+// NewACM returns a new ACM function. The name is used as the interface
+// string.
+func NewACM(name string) *ACM {
+	a := &ACM{name: name}
+	a.lineCoding = [7]byte{0x00, 0xc2, 0x01, 0x00, 0, 0, 8} // 115200 8N1
+	return a
+}
+
+// Init implements the usb.Function interface.
+func (a *ACM) Init(d *usb.Device) []byte {
+	a.dev = d
+	a.comm = d.NewInterface()
+	data := d.NewInterface()
+	a.notif = d.NewEndpoint(usb.Interrupt, true, 16)
+	a.out = d.NewEndpoint(usb.Bulk, false, 64)
+	a.in = d.NewEndpoint(usb.Bulk, true, 64)
+	str := int(d.NewString(a.name))
+	var desc []byte
+	desc = append(desc, usb.IfaceAssocDesc(a.comm, 2, 2, 2, 0, str)...)
+	desc = append(desc, usb.InterfaceDesc(a.comm, 0, 1, 2, 2, 0, str)...)
+	desc = append(desc,
+		5, usb.DescClassIface, 0x00, 0x10, 0x01, // header, CDC 1.10
+		5, usb.DescClassIface, 0x01, 0x00, byte(data), // call management
+		4, usb.DescClassIface, 0x02, 0x02, // ACM, line coding and state
+		5, usb.DescClassIface, 0x06, byte(a.comm), byte(data), // union
+	)
+	desc = append(desc, usb.EndpointDesc(a.notif, 16)...)
+	desc = append(desc, usb.InterfaceDesc(data, 0, 2, 0x0a, 0, 0, 0)...)
+	desc = append(desc, usb.EndpointDesc(a.out, 0)...)
+	desc = append(desc, usb.EndpointDesc(a.in, 0)...)
+	return desc
+}
+
+// Control implements the usb.Function interface.
+//
+//go:nosplit
+func (a *ACM) Control(req *usb.Setup, data []byte) (n int, ok bool) {
+	if req.RequestType&usb.TypeMask != usb.Class || int(req.Index) != a.comm {
+		return 0, false
+	}
+	switch req.Request {
+	case SetLineCoding:
+		copy(a.lineCoding[:], data)
+		return 0, true
+	case GetLineCoding:
+		return copy(data, a.lineCoding[:]), true
+	case SetControlLineState:
+		a.lineState = req.Value
+		return 0, true
+	case SendBreak:
+		return 0, true
+	}
+	return 0, false
+}
+
+// SetConfig implements the usb.Function interface.
+//
+//go:nosplit
+func (a *ACM) SetConfig(config int) {
+	if config == 0 {
+		a.lineState = 0
+	}
+}
+
+// LineState returns the control line state set by the host (see DTR, RTS).
+func (a *ACM) LineState() int {
+	return int(a.lineState)
+}
+
+// Baudrate returns the baudrate set by the host. It has no meaning for the USB
+// transfer but can be used for example by an USB to UART bridge.
+func (a *ACM) Baudrate() int {
+	lc := &a.lineCoding
+	return int(lc[0]) | int(lc[1])<<8 | int(lc[2])<<16 | int(lc[3])<<24
+}
+
+// SetReadTimeout sets the read timeout used by the Read method.
+func (a *ACM) SetReadTimeout(timeout time.Duration) {
+	a.out.SetTimeout(timeout)
+}
+
+// SetWriteTimeout sets the write timeout used by the Write method.
+func (a *ACM) SetWriteTimeout(timeout time.Duration) {
+	a.in.SetTimeout(timeout)
+}
+
+// Read reads the data sent by the host. If the device isn't configured Read
+// waits until it is configured by the host. Only one goroutine can read at
+// the same time.
+func (a *ACM) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return
+	}
+	for a.rpos == a.rlen {
+		a.rpos = 0
+		a.rlen, err = a.out.ReadPacket(a.rbuf[:])
+		if err == usb.ErrNotConfigured {
+			a.dev.WaitConfigured(-1)
+			continue
+		}
+		if err != nil {
+			return
+		}
+	}
+	n = copy(p, a.rbuf[a.rpos:a.rlen])
+	a.rpos += n
+	return
+}
+
+// Write sends the data to the host. The data is discarded if there is no
+// terminal program that reads it on the host side (DTR isn't asserted). Write
+// can be used concurrently by multiple goroutines.
+func (a *ACM) Write(p []byte) (n int, err error) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	if a.lineState&DTR == 0 {
+		return len(p), nil
+	}
+	for n < len(p) {
+		m := min(len(p)-n, a.in.MaxPacket())
+		if err = a.in.WritePacket(p[n : n+m]); err != nil {
+			return
+		}
+		n += m
+	}
+	if n != 0 && n%a.in.MaxPacket() == 0 {
+		// Terminate the transfer with a zero-length packet.
+		err = a.in.WritePacket(nil)
+	}
+	return
+}
@@ -0,0 +1,345 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/mmio"
+	"math/bits"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// ISR is the USB interrupt handler. It handles the enumeration, control
+// transfers on EP0 and wakes up the goroutines waiting for the endpoint
+// transfers.
+//
+//go:nosplit
+//go:nowritebarrierrec
+func (d *Device) ISR() {
+	p := d.p
+	ints := p.INTS.Load()
+	if ints&SETUP_REQ != 0 {
+		p.SIE_STATUS.Store(SETUP_REC) // write 1 to clear
+		d.handleSetup()
+	}
+	if ints&BUFF_STATUS != 0 {
+		bs := p.BUFF_STATUS.Load()
+		p.BUFF_STATUS.Store(bs) // write 1 to clear
+		for bs != 0 {
+			i := uint(bits.TrailingZeros32(bs))
+			bs &^= 1 << i
+			if n, dir := i>>1, i&1; n == 0 {
+				d.ep0Done()
+			} else {
+				e := &d.eps[n][dir]
+				e.ok = true
+				e.done.Wakeup()
+			}
+		}
+	}
+	if ints&BUS_RESET != 0 {
+		p.SIE_STATUS.Store(S_BUS_RESET)
+		p.ADDR_ENDP.Store(0)
+		d.addr = 0
+		d.addrPending = false
+		d.ctrl = ctrlIdle
+		d.setConfig(0)
+	}
+}
+
+// arm writes v to the buffer control register and next sets its AVAILABLE bit.
+// The delay between writes is required if clk_sys is faster than clk_usb.
+//
+//go:nosplit
+func arm(bc *mmio.R32[BUFCTRL], v BUFCTRL) {
+	bc.Store(v)
+	internal.BusyWaitAtLeastCycles(12)
+	bc.Store(v | BUF_AVAIL)
+}
+
+//go:nosplit
+func (d *Device) ep0Stall() {
+	dp := d.p.DPRAM()
+	d.p.EP_STALL_ARM.Store(3) // EP0_IN | EP0_OUT
+	dp.BUFCTRL[0][0].Store(BUF_STALL)
+	dp.BUFCTRL[0][1].Store(BUF_STALL)
+	d.ctrl = ctrlIdle
+}
+
+// ep0Send sends the next up to 64 bytes of the IN data stage.
+//
+//go:nosplit
+func (d *Device) ep0Send() {
+	n := min(d.ctrlN, 64)
+	src, dst := d.ctrlAddr, mmap.USB_DPRAM_BASE+ep0Buf
+	for i := uintptr(0); i < uintptr(n); i++ {
+		*(*byte)(unsafe.Pointer(dst + i)) = *(*byte)(unsafe.Pointer(src + i))
+	}
+	d.ctrlAddr += uintptr(n)
+	d.ctrlN -= n
+	arm(&d.p.DPRAM().BUFCTRL[0][0], BUF_FULL|d.ep0pid[0]|BUFCTRL(n))
+	d.ep0pid[0] ^= BUF_DATA1
+}
+
+//go:nosplit
+func (d *Device) ep0Recv() {
+	arm(&d.p.DPRAM().BUFCTRL[0][1], d.ep0pid[1]|64)
+	d.ep0pid[1] ^= BUF_DATA1
+}
+
+//go:nosplit
+func (d *Device) ep0Status() {
+	d.ctrl = ctrlStatusIn
+	d.ctrlN = 0
+	d.ep0Send()
+}
+
+//go:nosplit
+func (d *Device) handleSetup() {
+	dp := d.p.DPRAM()
+	w0, w1 := dp.SETUP[0].Load(), dp.SETUP[1].Load()
+	req := &d.setup
+	req.RequestType = uint8(w0)
+	req.Request = uint8(w0 >> 8)
+	req.Value = uint16(w0 >> 16)
+	req.Index = uint16(w1)
+	req.Length = uint16(w1 >> 16)
+	d.ep0pid = [2]BUFCTRL{BUF_DATA1, BUF_DATA1}
+	d.ctrl = ctrlIdle
+
+	if req.RequestType&DirIn != 0 {
+		addr, n, ok := d.setupIn(req)
+		if !ok {
+			d.ep0Stall()
+			return
+		}
+		n = min(n, int(req.Length))
+		d.ctrlAddr = addr
+		d.ctrlN = n
+		d.ctrlZLP = n != 0 && n%64 == 0 && n < int(req.Length)
+		d.ctrl = ctrlDataIn
+		d.ep0Send()
+		return
+	}
+	if req.Length == 0 {
+		if d.setupOut(req, d.ctrlBuf[:0]) {
+			d.ep0Status()
+		} else {
+			d.ep0Stall()
+		}
+		return
+	}
+	if int(req.Length) > len(d.ctrlBuf) {
+		d.ep0Stall()
+		return
+	}
+	d.ctrlN = 0
+	d.ctrl = ctrlDataOut
+	d.ep0Recv()
+}
+
+//go:nosplit
+func (d *Device) ep0Done() {
+	switch d.ctrl {
+	case ctrlDataIn:
+		if d.ctrlN != 0 || d.ctrlZLP {
+			if d.ctrlN == 0 {
+				d.ctrlZLP = false
+			}
+			d.ep0Send()
+			return
+		}
+		d.ctrl = ctrlStatusOut
+		d.ep0Recv()
+	case ctrlDataOut:
+		n := int(d.p.DPRAM().BUFCTRL[0][1].Load() & BUF_LEN)
+		n = min(n, int(d.setup.Length)-d.ctrlN)
+		src := mmap.USB_DPRAM_BASE + ep0Buf
+		for i := 0; i < n; i++ {
+			d.ctrlBuf[d.ctrlN+i] = *(*byte)(unsafe.Pointer(src + uintptr(i)))
+		}
+		d.ctrlN += n
+		if n == 64 && d.ctrlN < int(d.setup.Length) {
+			d.ep0Recv()
+			return
+		}
+		if d.setupOut(&d.setup, d.ctrlBuf[:d.ctrlN]) {
+			d.ep0Status()
+		} else {
+			d.ep0Stall()
+		}
+	case ctrlStatusIn:
+		if d.addrPending {
+			d.addrPending = false
+			d.p.ADDR_ENDP.Store(uint32(d.addr))
+		}
+		d.ctrl = ctrlIdle
+	case ctrlStatusOut:
+		d.ctrl = ctrlIdle
+	}
+}
+
+//go:nosplit
+func addrOf(b []byte) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+}
+
+//go:nosplit
+func (d *Device) ifcFunction(req *Setup) Function {
+	i := int(req.Index & 0xff)
+	if i >= d.nifc {
+		return nil
+	}
+	return d.funcs[d.ifcFunc[i]]
+}
+
+//go:nosplit
+func (d *Device) endpoint(req *Setup) *Endpoint {
+	n := int(req.Index & 0x0f)
+	dir := 1
+	if req.Index&0x80 != 0 {
+		dir = 0
+	}
+	if n == 0 || n >= d.epNext[dir] {
+		return nil
+	}
+	return &d.eps[n][dir]
+}
+
+// setupIn handles the device to host requests.
+//
+//go:nosplit
+func (d *Device) setupIn(req *Setup) (addr uintptr, n int, ok bool) {
+	buf := d.ctrlBuf[:]
+	addr = addrOf(buf)
+	switch req.RequestType & (TypeMask | RecipMask) {
+	case Standard | RecipDev:
+		switch req.Request {
+		case GetStatus:
+			buf[0], buf[1] = 0, 0
+			if d.selfPowered {
+				buf[0] = 1
+			}
+			return addr, 2, true
+		case GetConfiguration:
+			buf[0] = d.config
+			return addr, 1, true
+		case GetDescriptor:
+			switch req.Value >> 8 {
+			case DescDevice:
+				return addrOf(d.devDesc[:]), len(d.devDesc), true
+			case DescConfig:
+				return addrOf(d.cfgDesc), len(d.cfgDesc), true
+			case DescString:
+				if i := int(req.Value & 0xff); i < len(d.strDesc) {
+					s := d.strDesc[i]
+					return addrOf(s), len(s), true
+				}
+			}
+		}
+		return 0, 0, false
+	case Standard | RecipEP:
+		e := d.endpoint(req)
+		if req.Request != GetStatus || e == nil {
+			return 0, 0, false
+		}
+		buf[0], buf[1] = 0, 0
+		if e.Stalled() {
+			buf[0] = 1
+		}
+		return addr, 2, true
+	case Standard | RecipIfc:
+		if req.Request == GetStatus {
+			buf[0], buf[1] = 0, 0
+			return addr, 2, true
+		}
+	}
+	f := d.ifcFunction(req)
+	if f == nil {
+		return 0, 0, false
+	}
+	n, ok = f.Control(req, buf[:min(int(req.Length), len(buf))])
+	if !ok && req.RequestType&TypeMask == Standard && req.Request == GetInterface {
+		buf[0] = 0 // the only supported alternate setting
+		return addr, 1, true
+	}
+	return addr, n, ok
+}
+
+// setupOut handles the host to device requests.
+//
+//go:nosplit
+func (d *Device) setupOut(req *Setup, data []byte) bool {
+	switch req.RequestType & (TypeMask | RecipMask) {
+	case Standard | RecipDev:
+		switch req.Request {
+		case SetAddress:
+			d.addr = uint8(req.Value & 0x7f)
+			d.addrPending = true // set after the status stage
+			return true
+		case SetConfiguration:
+			if req.Value > 1 {
+				return false
+			}
+			d.setConfig(uint8(req.Value))
+			return true
+		case ClearFeature, SetFeature:
+			return req.Value == FeatureRemoteWkp
+		}
+		return false
+	case Standard | RecipEP:
+		e := d.endpoint(req)
+		if e == nil || req.Value != FeatureEPHalt {
+			return false
+		}
+		switch req.Request {
+		case SetFeature:
+			e.SetStall(true)
+			return true
+		case ClearFeature:
+			e.SetStall(false)
+			return true
+		}
+		return false
+	}
+	f := d.ifcFunction(req)
+	if f == nil {
+		return false
+	}
+	_, ok := f.Control(req, data)
+	if !ok && req.RequestType&TypeMask == Standard && req.Request == SetInterface {
+		return req.Value == 0 // the only supported alternate setting
+	}
+	return ok
+}
+
+// setConfig resets the state of all endpoints and informs the functions about
+// the configuration change.
+//
+//go:nosplit
+func (d *Device) setConfig(cfg uint8) {
+	if cfg == 0 && d.config == 0 {
+		return
+	}
+	d.config = cfg
+	d.epoch++
+	dp := d.p.DPRAM()
+	for dir := range 2 {
+		for n := 1; n < d.epNext[dir]; n++ {
+			dp.BUFCTRL[n][dir].Store(0)
+			e := &d.eps[n][dir]
+			e.pid = 0
+			e.done.Wakeup()
+		}
+	}
+	for _, f := range d.funcs {
+		f.SetConfig(int(cfg))
+	}
+	if cfg != 0 {
+		d.cfgNote.Wakeup()
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// A Function represents a USB function (a class driver) that can be provided
+// to the Device.Setup method. The Control and SetConfig methods are called by
+// the Device ISR so they must not block, allocate or store pointers.
+type Function interface {
+	// Init is called by Device.Setup. It should allocate the interfaces,
+	// endpoints and strings used by the function (see Device.NewInterface,
+	// Device.NewEndpoint, Device.NewString) and return the function part of
+	// the configuration descriptor.
+	Init(d *Device) (desc []byte)
+
+	// Control handles a control request addressed to one of the function
+	// interfaces. In case of the host to device request the data contains the
+	// data received in the data stage. In case of the device to host request
+	// Control should write the response to data and return its length. Control
+	// returns ok == false to reject the request (STALL).
+	Control(req *Setup, data []byte) (n int, ok bool)
+
+	// SetConfig informs the function about the device configuration changes.
+	// The config == 0 means the device isn't configured anymore (e.g. it has
+	// been reset or disconnected by the host).
+	SetConfig(config int)
+}
+
+// DeviceInfo contains the information used to build the device descriptor.
+type DeviceInfo struct {
+	VendorID     uint16
+	ProductID    uint16
+	Release      uint16 // device release number in BCD
+	Manufacturer string
+	Product      string
+	Serial       string
+	MaxPower     int // maximum power consumption from the bus in mA
+	SelfPowered  bool
+}
+
+const (
+	maxIfaces  = 16
+	ctrlBufLen = 256
+)
+
+// EP0 state
+const (
+	ctrlIdle = iota
+	ctrlDataIn
+	ctrlDataOut
+	ctrlStatusIn
+	ctrlStatusOut
+)
+
+// A Device is a driver for the USB controller working in the device mode. It
+// handles the enumeration and the standard requests on EP0 and delegates the
+// class specific requests to the functions provided to the Setup method.
+type Device struct {
+	p *Periph
+
+	devDesc [18]byte
+	cfgDesc []byte
+	strDesc [][]byte
+	funcs   []Function
+	ifcFunc [maxIfaces]int8
+	nifc    int
+	cur     int
+	epNext  [2]int
+	bufNext int
+	eps     [16][2]Endpoint
+
+	setup       Setup
+	ctrl        uint8
+	ctrlAddr    uintptr
+	ctrlN       int
+	ctrlZLP     bool
+	ep0pid      [2]BUFCTRL
+	addr        uint8
+	addrPending bool
+	selfPowered bool
+	config      uint8
+	epoch       uint32
+	cfgNote     rtos.Note
+	ctrlBuf     [ctrlBufLen]byte
+}
+
+// NewDevice returns a new device mode driver for p.
+func NewDevice(p *Periph) *Device {
+	return &Device{p: p}
+}
+
+// Periph returns the underlying USB controller.
+func (d *Device) Periph() *Periph {
+	return d.p
+}
+
+// Setup resets the USB controller and configures it to work in the device
+// mode. It builds the device, configuration and string descriptors using info
+// and the descriptors provided by the functions. Setup doesn't connect the
+// device to the bus (see Connect).
+func (d *Device) Setup(info *DeviceInfo, funcs ...Function) {
+	p := d.p
+	p.SetReset(true)
+	p.SetReset(false)
+	for a := mmap.USB_DPRAM_BASE; a < mmap.USB_DPRAM_BASE+dpramSize; a += 4 {
+		(*mmio.U32)(unsafe.Pointer(a)).Store(0)
+	}
+
+	d.funcs = funcs
+	d.nifc = 0
+	d.epNext = [2]int{1, 1}
+	d.bufNext = dpramFirst
+	d.selfPowered = info.SelfPowered
+	d.strDesc = [][]byte{{4, DescString, 0x09, 0x04}} // English (US)
+
+	d.devDesc = [18]byte{
+		18, DescDevice,
+		0x00, 0x02, // USB 2.0
+		0xef, 0x02, 0x01, // Miscellaneous class, IAD
+		64, // EP0 max packet size
+		byte(info.VendorID), byte(info.VendorID >> 8),
+		byte(info.ProductID), byte(info.ProductID >> 8),
+		byte(info.Release), byte(info.Release >> 8),
+		d.NewString(info.Manufacturer),
+		d.NewString(info.Product),
+		d.NewString(info.Serial),
+		1, // number of configurations
+	}
+
+	attr := byte(0x80)
+	if info.SelfPowered {
+		attr |= 0x40
+	}
+	cfg := []byte{9, DescConfig, 0, 0, 0, 1, 0, attr, byte(info.MaxPower / 2)}
+	for i, f := range funcs {
+		d.cur = i
+		cfg = append(cfg, f.Init(d)...)
+	}
+	cfg[2] = byte(len(cfg))
+	cfg[3] = byte(len(cfg) >> 8)
+	cfg[4] = byte(d.nifc)
+	d.cfgDesc = cfg
+
+	p.USB_MUXING.Store(TO_PHY | SOFTCON)
+	p.USB_PWR.Store(PWR_VBUS_DETECT | PWR_VBUS_DETECT_OVERRIDE_EN)
+	p.MAIN_CTRL.Store(CONTROLLER_EN) // also removes the PHY isolation
+	p.SIE_CTRL.Store(EP0_INT_1BUF)
+	p.INTE.Store(BUFF_STATUS | BUS_RESET | SETUP_REQ)
+}
+
+// NewString adds the string descriptor for s and returns its index. It
+// returns 0 (no string) if s is empty. NewString can be called only by
+// Setup or by Function.Init.
+func (d *Device) NewString(s string) uint8 {
+	if s == "" {
+		return 0
+	}
+	if len(d.strDesc) > 255 {
+		panic("usb: too many strings")
+	}
+	d.strDesc = append(d.strDesc, stringDesc(s))
+	return uint8(len(d.strDesc) - 1)
+}
+
+// NewInterface allocates a new interface number for the function being
+// initialized. It can be called only by Function.Init.
+func (d *Device) NewInterface() int {
+	if d.nifc >= maxIfaces {
+		panic("usb: too many interfaces")
+	}
+	n := d.nifc
+	d.ifcFunc[n] = int8(d.cur)
+	d.nifc++
+	return n
+}
+
+// NewEndpoint allocates a new endpoint and its buffer in DPRAM. It can be
+// called only by Function.Init.
+func (d *Device) NewEndpoint(typ EPType, in bool, maxPkt int) *Endpoint {
+	dir := 1
+	if in {
+		dir = 0
+	}
+	n := d.epNext[dir]
+	if n >= len(d.eps) {
+		panic("usb: too many endpoints")
+	}
+	if maxPkt <= 0 || maxPkt > 64 && (typ != Isochronous || maxPkt > 1023) {
+		panic("usb: bad max. packet size")
+	}
+	size := (maxPkt + 63) &^ 63
+	if d.bufNext+size > dpramSize {
+		panic("usb: out of DPRAM")
+	}
+	d.epNext[dir]++
+	e := &d.eps[n][dir]
+	e.d = d
+	e.num = uint8(n)
+	e.dir = uint8(dir)
+	e.typ = typ
+	e.maxPkt = uint16(maxPkt)
+	e.buf = mmap.USB_DPRAM_BASE + uintptr(d.bufNext)
+	e.timeout = -1
+	d.p.DPRAM().EPCTRL[n-1][dir].Store(
+		EP_EN | EP_INT_1BUF | EPCTRL(typ)<<EP_TYPEn | EPCTRL(d.bufNext),
+	)
+	d.bufNext += size
+	return e
+}
+
+// Connect connects the device to the bus (enables the pull-up resistor on the
+// DP line).
+func (d *Device) Connect() {
+	internal.AtomicSet(&d.p.SIE_CTRL, PULLUP_EN)
+}
+
+// Disconnect disconnects the device from the bus.
+func (d *Device) Disconnect() {
+	internal.AtomicClear(&d.p.SIE_CTRL, PULLUP_EN)
+}
+
+// Config returns the current configuration value set by the host. Zero means
+// the device isn't configured.
+func (d *Device) Config() int {
+	return int(d.config)
+}
+
+// WaitConfigured waits until the device is configured by the host. It reports
+// whether the device is configured or the timeout has occurred. Only one
+// goroutine can wait at the same time.
+func (d *Device) WaitConfigured(timeout time.Duration) bool {
+	for {
+		d.cfgNote.Clear()
+		if d.config != 0 {
+			return true
+		}
+		if !d.cfgNote.Sleep(timeout) {
+			return d.config != 0
+		}
+	}
+}
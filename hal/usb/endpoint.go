@@ -0,0 +1,155 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+)
+
+// An Endpoint represents a device endpoint allocated by Device.NewEndpoint.
+// An endpoint can be used by one goroutine at the same time.
+type Endpoint struct {
+	d       *Device
+	num     uint8
+	dir     uint8 // 0: IN, 1: OUT
+	typ     EPType
+	maxPkt  uint16
+	pid     BUFCTRL
+	buf     uintptr
+	timeout time.Duration
+	done    rtos.Note
+	ok      bool
+}
+
+var (
+	ErrNotConfigured = errors.New("usb: device not configured")
+	ErrTimeout       = errors.New("usb: timeout")
+	ErrHalted        = errors.New("usb: endpoint halted")
+)
+
+// Addr returns the endpoint address as used in the endpoint descriptor.
+func (e *Endpoint) Addr() uint8 {
+	if e.dir == 0 {
+		return e.num | 0x80
+	}
+	return e.num
+}
+
+// Type returns the endpoint type.
+func (e *Endpoint) Type() EPType {
+	return e.typ
+}
+
+// MaxPacket returns the maximum packet size.
+func (e *Endpoint) MaxPacket() int {
+	return int(e.maxPkt)
+}
+
+// SetTimeout sets the timeout for the ReadPacket and WritePacket methods. The
+// negative timeout (default) means no timeout.
+func (e *Endpoint) SetTimeout(timeout time.Duration) {
+	e.timeout = timeout
+}
+
+//go:nosplit
+func (e *Endpoint) bufctrl() *mmio.R32[BUFCTRL] {
+	return &e.d.p.DPRAM().BUFCTRL[e.num][e.dir]
+}
+
+// SetStall sets or clears the endpoint halt condition. Clearing the halt
+// condition also resets the data toggle to DATA0.
+//
+//go:nosplit
+func (e *Endpoint) SetStall(stall bool) {
+	bc := e.bufctrl()
+	if stall {
+		bc.Store(BUF_STALL)
+		e.done.Wakeup()
+		return
+	}
+	e.pid = 0
+	if bc.LoadBits(BUF_STALL) != 0 {
+		bc.Store(0)
+		e.done.Wakeup()
+	}
+}
+
+// Stalled reports whether the endpoint is halted.
+//
+//go:nosplit
+func (e *Endpoint) Stalled() bool {
+	return e.bufctrl().LoadBits(BUF_STALL) != 0
+}
+
+// WritePacket sends a single packet to the host. The length of p must not
+// exceed MaxPacket. WritePacket blocks until the host reads the packet or
+// timeout occurs.
+func (e *Endpoint) WritePacket(p []byte) error {
+	if len(p) > int(e.maxPkt) {
+		panic("usb: packet too long")
+	}
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(e.buf)), len(p)), p)
+	return e.transfer(BUF_FULL | BUFCTRL(len(p)))
+}
+
+// ReadPacket receives a single packet from the host. The length of p should
+// be at least MaxPacket, otherwise the excess data is lost. ReadPacket blocks
+// until the host sends a packet or timeout occurs.
+func (e *Endpoint) ReadPacket(p []byte) (n int, err error) {
+	if err = e.transfer(BUFCTRL(e.maxPkt)); err != nil {
+		return
+	}
+	n = min(int(e.bufctrl().Load()&BUF_LEN), len(p))
+	copy(p, unsafe.Slice((*byte)(unsafe.Pointer(e.buf)), n))
+	return
+}
+
+func (e *Endpoint) transfer(v BUFCTRL) error {
+	d := e.d
+	epoch := d.epoch
+	if d.config == 0 {
+		return ErrNotConfigured
+	}
+	if e.Stalled() {
+		return ErrHalted
+	}
+	e.ok = false
+	e.done.Clear() // memory barrier
+	arm(e.bufctrl(), v|e.pid)
+	if !e.done.Sleep(e.timeout) {
+		e.abort()
+		if !e.ok {
+			return ErrTimeout
+		}
+	}
+	if !e.ok {
+		// Woken up by the bus reset, configuration change or halt.
+		if d.epoch != epoch || d.config == 0 {
+			return ErrNotConfigured
+		}
+		return ErrHalted
+	}
+	e.pid ^= BUF_DATA1
+	return nil
+}
+
+// abort makes the endpoint buffer safe to modify by the CPU.
+func (e *Endpoint) abort() {
+	p := e.d.p
+	mask := uint32(1) << (e.num*2 + e.dir)
+	internal.AtomicSetU32(&p.EP_ABORT, mask)
+	for p.EP_ABORT_DONE.Load()&mask == 0 {
+	}
+	bc := e.bufctrl()
+	bc.Store(bc.Load() &^ BUF_AVAIL)
+	internal.AtomicClearU32(&p.EP_ABORT, mask)
+	p.EP_ABORT_DONE.Store(mask) // write 1 to clear
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hid implements the USB Human Interface Device class function.
+package hid
+
+import (
+	"time"
+
+	"github.com/embeddedgo/pico/hal/usb"
+)
+
+// Boot interface protocols.
+const (
+	BootNone     = 0
+	BootKeyboard = 1
+	BootMouse    = 2
+)
+
+// Class specific descriptor types.
+const (
+	DescHID    = 0x21
+	DescReport = 0x22
+)
+
+// Class specific requests.
+const (
+	GetReport   = 0x01
+	GetIdle     = 0x02
+	GetProtocol = 0x03
+	SetReport   = 0x09
+	SetIdle     = 0x0a
+	SetProtocol = 0x0b
+)
+
+// HID implements the HID function with one IN (device to host) and an
+// optional OUT (host to device) interrupt endpoint.
+type HID struct {
+	name     string
+	report   []byte
+	boot     uint8
+	inSize   int
+	outSize  int
+	interval int
+
+	ifc      int
+	in       *usb.Endpoint
+	out      *usb.Endpoint
+	hidDesc  [9]byte
+	protocol uint8
+	idle     uint8
+	last     [64]byte
+}
+
+// New returns a new HID function that uses the report descriptor rd. The boot
+// parameter selects the boot interface protocol (BootNone, BootKeyboard,
+// BootMouse). The inSize and outSize are the sizes of the input and output
+// reports (up to 64 bytes). If outSize is zero the function has no OUT
+// endpoint.
+func New(name string, rd []byte, boot, inSize, outSize int) *HID {
+	if inSize <= 0 || inSize > 64 || outSize < 0 || outSize > 64 {
+		panic("hid: bad report size")
+	}
+	return &HID{
+		name: name, report: rd, boot: uint8(boot),
+		inSize: inSize, outSize: outSize, interval: 10,
+		protocol: 1,
+	}
+}
+
+// NewKeyboard returns a new HID function that implements the boot keyboard.
+func NewKeyboard(name string) *HID {
+	return New(name, KeyboardReportDesc(), BootKeyboard, 8, 1)
+}
+
+// NewMouse returns a new HID function that implements the boot mouse.
+func NewMouse(name string) *HID {
+	return New(name, MouseReportDesc(), BootMouse, 4, 0)
+}
+
+// Init implements the usb.Function interface.
+func (h *HID) Init(d *usb.Device) []byte {
+	h.ifc = d.NewInterface()
+	h.in = d.NewEndpoint(usb.Interrupt, true, h.inSize)
+	numEP := 1
+	if h.outSize != 0 {
+		h.out = d.NewEndpoint(usb.Interrupt, false, h.outSize)
+		numEP++
+	}
+	n := len(h.report)
+	h.hidDesc = [9]byte{
+		9, DescHID, 0x11, 0x01, 0, 1, DescReport, byte(n), byte(n >> 8),
+	}
+	subclass := 0
+	if h.boot != BootNone {
+		subclass = 1
+	}
+	str := int(d.NewString(h.name))
+	desc := usb.InterfaceDesc(h.ifc, 0, numEP, 3, subclass, int(h.boot), str)
+	desc = append(desc, h.hidDesc[:]...)
+	desc = append(desc, usb.EndpointDesc(h.in, h.interval)...)
+	if h.out != nil {
+		desc = append(desc, usb.EndpointDesc(h.out, h.interval)...)
+	}
+	return desc
+}
+
+// Control implements the usb.Function interface.
+//
+//go:nosplit
+func (h *HID) Control(req *usb.Setup, data []byte) (n int, ok bool) {
+	if int(req.Index) != h.ifc {
+		return 0, false
+	}
+	switch req.RequestType & usb.TypeMask {
+	case usb.Standard:
+		if req.Request != usb.GetDescriptor {
+			return 0, false
+		}
+		switch req.Value >> 8 {
+		case DescReport:
+			return copy(data, h.report), true
+		case DescHID:
+			return copy(data, h.hidDesc[:]), true
+		}
+	case usb.Class:
+		switch req.Request {
+		case GetReport:
+			return copy(data, h.last[:h.inSize]), true
+		case SetReport:
+			return 0, true
+		case GetIdle:
+			return copy(data, []byte{h.idle}), true
+		case SetIdle:
+			h.idle = uint8(req.Value >> 8)
+			return 0, true
+		case GetProtocol:
+			return copy(data, []byte{h.protocol}), true
+		case SetProtocol:
+			h.protocol = uint8(req.Value)
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// SetConfig implements the usb.Function interface.
+//
+//go:nosplit
+func (h *HID) SetConfig(config int) {
+	h.protocol = 1
+	h.idle = 0
+}
+
+// SetInterval sets the polling interval of the interrupt endpoints (in ms).
+// It must be called before the function is used by usb.Device.Setup.
+func (h *HID) SetInterval(interval int) {
+	h.interval = interval
+}
+
+// BootProtocol reports whether the host selected the boot protocol.
+func (h *HID) BootProtocol() bool {
+	return h.protocol == 0
+}
+
+// SetTimeout sets the timeout for the report transfers.
+func (h *HID) SetTimeout(timeout time.Duration) {
+	h.in.SetTimeout(timeout)
+	if h.out != nil {
+		h.out.SetTimeout(timeout)
+	}
+}
+
+// WriteReport sends the input report r to the host. It blocks until the host
+// reads the report.
+func (h *HID) WriteReport(r []byte) error {
+	copy(h.last[:h.inSize], r)
+	return h.in.WritePacket(r)
+}
+
+// ReadReport receives an output report from the host. It panics if the
+// function has no OUT endpoint.
+func (h *HID) ReadReport(r []byte) (n int, err error) {
+	if h.out == nil {
+		panic("hid: no OUT endpoint")
+	}
+	return h.out.ReadPacket(r)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hid
+
+// Keyboard modifier bits.
+const (
+	LeftCtrl   = 1 << 0
+	LeftShift  = 1 << 1
+	LeftAlt    = 1 << 2
+	LeftGUI    = 1 << 3
+	RightCtrl  = 1 << 4
+	RightShift = 1 << 5
+	RightAlt   = 1 << 6
+	RightGUI   = 1 << 7
+)
+
+// Some keyboard key codes (usage IDs). Letters start from KeyA, digits from
+// Key1 (Key0 follows Key9). The modifier keys start from KeyLeftCtrl in the
+// order of the modifier bits (KeyLeftCtrl+n corresponds to the 1<<n bit).
+const (
+	KeyA         = 0x04
+	Key1         = 0x1e
+	Key0         = 0x27
+	KeyEnter     = 0x28
+	KeyEsc       = 0x29
+	KeyBackspace = 0x2a
+	KeyTab       = 0x2b
+	KeySpace     = 0x2c
+	KeyF1        = 0x3a
+	KeyDelete    = 0x4c
+	KeyRight     = 0x4f
+	KeyLeft      = 0x50
+	KeyDown      = 0x51
+	KeyUp        = 0x52
+	KeyLeftCtrl  = 0xe0
+)
+
+// Keyboard LED bits (the output report).
+const (
+	NumLock    = 1 << 0
+	CapsLock   = 1 << 1
+	ScrollLock = 1 << 2
+)
+
+// US keyboard layout of the printable ASCII characters from ' ' to '~'. Every
+// character is encoded using two bytes: key code and shift flag.
+const usLayout = "" +
+	"\x2c\x00\x1e\x01\x34\x01\x20\x01\x21\x01\x22\x01\x24\x01\x34\x00" + // !"#$%&'
+	"\x26\x01\x27\x01\x25\x01\x2e\x01\x36\x00\x2d\x00\x37\x00\x38\x00" + // ()*+,-./
+	"\x27\x00\x1e\x00\x1f\x00\x20\x00\x21\x00\x22\x00\x23\x00\x24\x00" + // 01234567
+	"\x25\x00\x26\x00\x33\x01\x33\x00\x36\x01\x2e\x00\x37\x01\x38\x01" + // 89:;<=>?
+	"\x1f\x01" + // @
+	"\x04\x01\x05\x01\x06\x01\x07\x01\x08\x01\x09\x01\x0a\x01\x0b\x01" + // A-H
+	"\x0c\x01\x0d\x01\x0e\x01\x0f\x01\x10\x01\x11\x01\x12\x01\x13\x01" + // I-P
+	"\x14\x01\x15\x01\x16\x01\x17\x01\x18\x01\x19\x01\x1a\x01\x1b\x01" + // Q-X
+	"\x1c\x01\x1d\x01" + // YZ
+	"\x2f\x00\x31\x00\x30\x00\x23\x01\x2d\x01\x35\x00" + // [\]^_`
+	"\x04\x00\x05\x00\x06\x00\x07\x00\x08\x00\x09\x00\x0a\x00\x0b\x00" + // a-h
+	"\x0c\x00\x0d\x00\x0e\x00\x0f\x00\x10\x00\x11\x00\x12\x00\x13\x00" + // i-p
+	"\x14\x00\x15\x00\x16\x00\x17\x00\x18\x00\x19\x00\x1a\x00\x1b\x00" + // q-x
+	"\x1c\x00\x1d\x00" + // yz
+	"\x2f\x01\x31\x01\x30\x01\x35\x01" // {|}~
+
+// ASCIIKey returns the modifiers and the key code that produce the ASCII
+// character c on the US keyboard. It returns key == 0 if c has no
+// corresponding key.
+func ASCIIKey(c byte) (mod, key byte) {
+	switch c {
+	case '\r', '\n':
+		return 0, KeyEnter
+	case '\b':
+		return 0, KeyBackspace
+	case '\t':
+		return 0, KeyTab
+	case 0x1b:
+		return 0, KeyEsc
+	case 0x7f:
+		return 0, KeyDelete
+	}
+	if c < ' ' || c > '~' {
+		return 0, 0
+	}
+	i := int(c-' ') * 2
+	if usLayout[i+1] != 0 {
+		mod = LeftShift
+	}
+	return mod, usLayout[i]
+}
+
+// PressKeys sends the keyboard input report with the modifiers mod and up to 6
+// pressed keys. Call it without keys to release all the keys.
+func (h *HID) PressKeys(mod byte, keys ...byte) error {
+	var r [8]byte
+	r[0] = mod
+	copy(r[2:], keys)
+	return h.WriteReport(r[:])
+}
+
+// TypeKey presses and releases the key.
+func (h *HID) TypeKey(mod, key byte) error {
+	if err := h.PressKeys(mod, key); err != nil {
+		return err
+	}
+	return h.PressKeys(0)
+}
+
+// MoveMouse sends the mouse input report.
+func (h *HID) MoveMouse(buttons byte, dx, dy, wheel int8) error {
+	return h.WriteReport([]byte{buttons, byte(dx), byte(dy), byte(wheel)})
+}
+
+// KeyASCII returns the ASCII character produced on the US keyboard by the key
+// pressed with the modifiers mod. It returns 0 if the key doesn't produce any
+// character. KeyASCII is the inverse of ASCIIKey except the Ctrl modifier that
+// produces the control characters from letters.
+func KeyASCII(mod, key byte) byte {
+	switch key {
+	case KeyEnter:
+		return '\r'
+	case KeyBackspace:
+		return '\b'
+	case KeyTab:
+		return '\t'
+	case KeyEsc:
+		return 0x1b
+	case KeyDelete:
+		return 0x7f
+	}
+	shift := byte(0)
+	if mod&(LeftShift|RightShift) != 0 {
+		shift = 1
+	}
+	for i := 0; i < len(usLayout); i += 2 {
+		if usLayout[i] == key && usLayout[i+1] == shift {
+			c := byte(i/2) + ' '
+			if mod&(LeftCtrl|RightCtrl) != 0 && c >= '@' && c <= '~' {
+				c &= 0x1f
+			}
+			return c
+		}
+	}
+	return 0
+}
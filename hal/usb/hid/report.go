@@ -0,0 +1,80 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hid
+
+// KeyboardReportDesc returns the report descriptor of the boot keyboard. The
+// input report consists of 8 bytes: the modifier bits, a reserved byte and up
+// to 6 key codes. The 1-byte output report contains the LED bits.
+func KeyboardReportDesc() []byte {
+	return []byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x06, // Usage (Keyboard)
+		0xa1, 0x01, // Collection (Application)
+		0x05, 0x07, //   Usage Page (Key Codes)
+		0x19, 0xe0, //   Usage Minimum (224)
+		0x29, 0xe7, //   Usage Maximum (231)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x01, //   Logical Maximum (1)
+		0x75, 0x01, //   Report Size (1)
+		0x95, 0x08, //   Report Count (8)
+		0x81, 0x02, //   Input (Data, Variable, Absolute): modifiers
+		0x95, 0x01, //   Report Count (1)
+		0x75, 0x08, //   Report Size (8)
+		0x81, 0x01, //   Input (Constant): reserved byte
+		0x95, 0x05, //   Report Count (5)
+		0x75, 0x01, //   Report Size (1)
+		0x05, 0x08, //   Usage Page (LEDs)
+		0x19, 0x01, //   Usage Minimum (1)
+		0x29, 0x05, //   Usage Maximum (5)
+		0x91, 0x02, //   Output (Data, Variable, Absolute): LEDs
+		0x95, 0x01, //   Report Count (1)
+		0x75, 0x03, //   Report Size (3)
+		0x91, 0x01, //   Output (Constant): padding
+		0x95, 0x06, //   Report Count (6)
+		0x75, 0x08, //   Report Size (8)
+		0x15, 0x00, //   Logical Minimum (0)
+		0x25, 0x65, //   Logical Maximum (101)
+		0x05, 0x07, //   Usage Page (Key Codes)
+		0x19, 0x00, //   Usage Minimum (0)
+		0x29, 0x65, //   Usage Maximum (101)
+		0x81, 0x00, //   Input (Data, Array): key codes
+		0xc0, // End Collection
+	}
+}
+
+// MouseReportDesc returns the report descriptor of the boot mouse with a
+// wheel. The input report consists of 4 bytes: the button bits, X, Y and
+// wheel relative movements.
+func MouseReportDesc() []byte {
+	return []byte{
+		0x05, 0x01, // Usage Page (Generic Desktop)
+		0x09, 0x02, // Usage (Mouse)
+		0xa1, 0x01, // Collection (Application)
+		0x09, 0x01, //   Usage (Pointer)
+		0xa1, 0x00, //   Collection (Physical)
+		0x05, 0x09, //     Usage Page (Buttons)
+		0x19, 0x01, //     Usage Minimum (1)
+		0x29, 0x03, //     Usage Maximum (3)
+		0x15, 0x00, //     Logical Minimum (0)
+		0x25, 0x01, //     Logical Maximum (1)
+		0x95, 0x03, //     Report Count (3)
+		0x75, 0x01, //     Report Size (1)
+		0x81, 0x02, //     Input (Data, Variable, Absolute): buttons
+		0x95, 0x01, //     Report Count (1)
+		0x75, 0x05, //     Report Size (5)
+		0x81, 0x01, //     Input (Constant): padding
+		0x05, 0x01, //     Usage Page (Generic Desktop)
+		0x09, 0x30, //     Usage (X)
+		0x09, 0x31, //     Usage (Y)
+		0x09, 0x38, //     Usage (Wheel)
+		0x15, 0x81, //     Logical Minimum (-127)
+		0x25, 0x7f, //     Logical Maximum (127)
+		0x75, 0x08, //     Report Size (8)
+		0x95, 0x03, //     Report Count (3)
+		0x81, 0x06, //     Input (Data, Variable, Relative): X, Y, wheel
+		0xc0, //   End Collection
+		0xc0, // End Collection
+	}
+}
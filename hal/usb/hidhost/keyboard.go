@@ -0,0 +1,181 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hidhost implements the USB host side drivers for the Human Interface
+// Devices.
+package hidhost
+
+import (
+	"errors"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/hid"
+)
+
+var ErrNotKeyboard = errors.New("hidhost: not a boot keyboard")
+
+// A KeyEvent describes a key press or release. The modifier keys generate the
+// events too, with the Key field set to hid.KeyLeftCtrl+n for the 1<<n
+// modifier bit.
+type KeyEvent struct {
+	Mod  byte // modifiers at the time of the event (hid.LeftCtrl, ...)
+	Key  byte // key code (usage ID, see hid.KeyA, ..., hid.KeyLeftCtrl)
+	Down bool // key pressed (true) or released (false)
+}
+
+// ASCII returns the ASCII character produced by the pressed key using the US
+// keyboard layout. It returns 0 for the released keys and for the keys that
+// produce no characters.
+func (e KeyEvent) ASCII() byte {
+	if !e.Down {
+		return 0
+	}
+	return hid.KeyASCII(e.Mod, e.Key)
+}
+
+// Keyboard is a driver for an USB keyboard that supports the boot protocol.
+type Keyboard struct {
+	d      *usb.HostDevice
+	ifc    uint16
+	in     *usb.Pipe
+	events chan KeyEvent
+}
+
+// NewKeyboard finds the boot keyboard interface of the device d, configures
+// the device to use the boot protocol and starts a goroutine that delivers the
+// key events on the channel returned by Events.
+func NewKeyboard(d *usb.HostDevice) (*Keyboard, error) {
+	cfg := d.ConfigDesc()
+	if len(cfg) < 9 {
+		return nil, ErrNotKeyboard
+	}
+	var (
+		k      Keyboard
+		kbd    bool
+		found  bool
+		ep     uint8
+		maxPkt int
+		ival   int
+	)
+	for i := 0; i+2 <= len(cfg) && cfg[i] >= 2; i += int(cfg[i]) {
+		desc := cfg[i:min(i+int(cfg[i]), len(cfg))]
+		switch desc[1] {
+		case usb.DescInterface:
+			if len(desc) < 9 {
+				return nil, ErrNotKeyboard
+			}
+			// HID class, boot interface subclass, keyboard protocol
+			kbd = desc[5] == 3 && desc[6] == 1 && desc[7] == hid.BootKeyboard
+			if kbd {
+				k.ifc = uint16(desc[2])
+			}
+		case usb.DescEndpoint:
+			if !kbd || len(desc) < 7 || desc[2]&0x80 == 0 || desc[3]&3 != 3 {
+				continue
+			}
+			ep = desc[2]
+			maxPkt = int(desc[4]) | int(desc[5])<<8
+			ival = int(desc[6])
+			found = true
+		}
+		if found {
+			break
+		}
+	}
+	if !found {
+		return nil, ErrNotKeyboard
+	}
+	if err := d.SetConfiguration(int(cfg[5])); err != nil {
+		return nil, err
+	}
+	req := usb.Setup{
+		RequestType: usb.Class | usb.RecipIfc,
+		Request:     hid.SetProtocol,
+		Value:       0, // boot protocol
+		Index:       k.ifc,
+	}
+	if _, err := d.Control(&req, nil); err != nil {
+		return nil, err
+	}
+	// Report only the changes. Some keyboards don't support SetIdle.
+	req.Request = hid.SetIdle
+	d.Control(&req, nil)
+
+	in, err := d.NewPipe(ep, usb.Interrupt, min(maxPkt, 64), ival)
+	if err != nil {
+		return nil, err
+	}
+	k.d = d
+	k.in = in
+	k.events = make(chan KeyEvent, 16)
+	go k.run()
+	return &k, nil
+}
+
+// Events returns the channel of key events. The channel is closed when the
+// keyboard is disconnected.
+func (k *Keyboard) Events() <-chan KeyEvent {
+	return k.events
+}
+
+// SetLEDs sets the keyboard LEDs (see hid.NumLock, hid.CapsLock,
+// hid.ScrollLock).
+func (k *Keyboard) SetLEDs(leds byte) error {
+	req := usb.Setup{
+		RequestType: usb.Class | usb.RecipIfc,
+		Request:     hid.SetReport,
+		Value:       2 << 8, // output report
+		Index:       k.ifc,
+		Length:      1,
+	}
+	_, err := k.d.Control(&req, []byte{leds})
+	return err
+}
+
+func (k *Keyboard) run() {
+	var prev, r [8]byte
+	for {
+		n, err := k.in.ReadPacket(r[:])
+		if err == usb.ErrDisconnected {
+			break
+		}
+		if err != nil {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		if n < 3 || r[2] == 1 {
+			continue // short report or too many keys pressed (ErrorRollOver)
+		}
+		clear(r[n:])
+		mod := r[0]
+		for i, ch := 0, prev[0]^mod; ch != 0; i, ch = i+1, ch>>1 {
+			if ch&1 != 0 {
+				k.events <- KeyEvent{mod, hid.KeyLeftCtrl + byte(i), mod>>i&1 != 0}
+			}
+		}
+		for _, key := range prev[2:] {
+			if key != 0 && !contains(r[2:], key) {
+				k.events <- KeyEvent{mod, key, false}
+			}
+		}
+		for _, key := range r[2:] {
+			if key != 0 && !contains(prev[2:], key) {
+				k.events <- KeyEvent{mod, key, true}
+			}
+		}
+		prev = r
+	}
+	k.in.Close()
+	close(k.events)
+}
+
+func contains(keys []byte, key byte) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
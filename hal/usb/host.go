@@ -0,0 +1,431 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/mmio"
+	"embedded/rtos"
+	"errors"
+	"math/bits"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+var (
+	ErrDisconnected = errors.New("usb: device disconnected")
+	ErrNoResponse   = errors.New("usb: no response from device")
+	ErrProtocol     = errors.New("usb: protocol error")
+	ErrNoPipe       = errors.New("usb: no free interrupt pipe")
+)
+
+// Device speed as reported by Host.Speed.
+const (
+	Disconnected = 0
+	LowSpeed     = 1
+	FullSpeed    = 2
+)
+
+// Transaction status of the EPX endpoint and the interrupt pipes set by the
+// ISR.
+const (
+	epxIdle = iota
+	epxBusy
+	epxDone
+	epxStall
+	epxNoResp
+	epxError
+	epxDisconn
+)
+
+const sieHost = SOF_EN | KEEP_ALIVE_EN | PULLDOWN_EN | EP0_INT_1BUF
+
+// Host is a driver for the USB controller working in the host mode. It
+// supports one device connected directly to the USB port (hubs aren't
+// supported).
+type Host struct {
+	p       *Periph
+	mu      sync.Mutex // serializes the EPX transfers
+	speed   uint8
+	epoch   uint32 // incremented on every connect/disconnect
+	conn    rtos.Note
+	epxNote rtos.Note
+	epxStat uint8
+	addr    uint8
+	pipes   [15]*Pipe // interrupt pipes polled by the hardware
+}
+
+// NewHost returns a new host mode driver for p.
+func NewHost(p *Periph) *Host {
+	return &Host{p: p}
+}
+
+// Periph returns the underlying USB controller.
+func (h *Host) Periph() *Periph {
+	return h.p
+}
+
+// Setup resets the USB controller and configures it to work in the host mode.
+// The Pico boards do not control the VBUS so the connected device must be
+// powered externally (e.g. by the VSYS pin through an USB OTG adapter).
+func (h *Host) Setup() {
+	p := h.p
+	p.SetReset(true)
+	p.SetReset(false)
+	for a := mmap.USB_DPRAM_BASE; a < mmap.USB_DPRAM_BASE+dpramSize; a += 4 {
+		(*mmio.U32)(unsafe.Pointer(a)).Store(0)
+	}
+	p.USB_MUXING.Store(TO_PHY | SOFTCON)
+	p.USB_PWR.Store(PWR_VBUS_DETECT | PWR_VBUS_DETECT_OVERRIDE_EN)
+	p.MAIN_CTRL.Store(CONTROLLER_EN | HOST_NDEVICE)
+	p.SIE_CTRL.Store(sieHost)
+	p.INTE.Store(HOST_CONN_DIS | TRANS_COMPLETE | BUFF_STATUS | STALL |
+		ERROR_RX_TIMEOUT | ERROR_DATA_SEQ | ERROR_CRC | ERROR_BIT_STUFF |
+		ERROR_RX_OVERFLOW)
+}
+
+// ISR is the USB interrupt handler in the host mode.
+//
+//go:nosplit
+//go:nowritebarrierrec
+func (h *Host) ISR() {
+	p := h.p
+	ints := p.INTS.Load()
+	if ints&HOST_CONN_DIS != 0 {
+		h.speed = uint8(p.SIE_STATUS.LoadBits(SPEED) >> SPEEDn)
+		p.SIE_STATUS.Store(SPEED) // write 1 to clear
+		h.epoch++
+		h.epxEnd(epxDisconn)
+		for _, pp := range h.pipes {
+			if pp != nil {
+				pp.end(epxDisconn)
+			}
+		}
+		h.conn.Wakeup()
+	}
+	// The errors don't identify the endpoint. They are assigned to the EPX
+	// transaction, if any in progress, or to the armed interrupt endpoints.
+	if ints&STALL != 0 {
+		p.SIE_STATUS.Store(STALL_REC)
+		h.errEnd(epxStall)
+	}
+	if ints&ERROR_RX_TIMEOUT != 0 {
+		p.SIE_STATUS.Store(RX_TIMEOUT)
+		h.errEnd(epxNoResp)
+	}
+	const errs = ERROR_DATA_SEQ | ERROR_CRC | ERROR_BIT_STUFF | ERROR_RX_OVERFLOW
+	if ints&errs != 0 {
+		p.SIE_STATUS.Store(DATA_SEQ_ERROR | CRC_ERROR | BIT_STUFF_ERROR |
+			RX_OVERFLOW)
+		h.errEnd(epxError)
+	}
+	// TRANS_COMPLETE is also raised by the interrupt endpoints so it's used
+	// only to complete the SETUP stage (it doesn't use any buffer).
+	if ints&TRANS_COMPLETE != 0 {
+		p.SIE_STATUS.Store(S_TRANS_COMPLETE)
+		if p.SIE_CTRL.Load()&SEND_SETUP != 0 {
+			h.epxEnd(epxDone)
+		}
+	}
+	if ints&BUFF_STATUS != 0 {
+		bs := p.BUFF_STATUS.Load()
+		p.BUFF_STATUS.Store(bs) // write 1 to clear
+		if bs&3 != 0 {
+			h.epxEnd(epxDone)
+		}
+		bs >>= 2
+		for bs != 0 {
+			i := uint(bits.TrailingZeros32(bs))
+			bs &^= 1 << i
+			if pp := h.pipes[i>>1]; pp != nil {
+				pp.end(epxDone)
+			}
+		}
+	}
+}
+
+// errEnd ends the EPX transaction in progress or, if there is none, the
+// transactions of the enabled interrupt endpoints with armed buffers.
+//
+//go:nosplit
+func (h *Host) errEnd(status uint8) {
+	if h.epxStat == epxBusy {
+		h.epxEnd(status)
+		return
+	}
+	p := h.p
+	en := p.INT_EP_CTRL.Load()
+	dp := p.HostDPRAM()
+	for n, pp := range h.pipes {
+		if pp != nil && en&(1<<(n+1)) != 0 &&
+			dp.BUFCTRL[n+1][0].Load()&BUF_AVAIL != 0 {
+			pp.end(status)
+		}
+	}
+}
+
+//go:nosplit
+func (h *Host) epxEnd(status uint8) {
+	if h.epxStat == epxBusy {
+		h.epxStat = status
+		h.epxNote.Wakeup()
+	}
+}
+
+// Speed returns the speed of the connected device or Disconnected.
+func (h *Host) Speed() int {
+	return int(h.speed)
+}
+
+// WaitConnect waits until a device is connected to the USB port. It returns
+// false if timeout occurs. The negative timeout means no timeout.
+func (h *Host) WaitConnect(timeout time.Duration) bool {
+	for {
+		h.conn.Clear() // memory barrier
+		if h.speed != Disconnected {
+			return true
+		}
+		if !h.conn.Sleep(timeout) {
+			return h.speed != Disconnected
+		}
+	}
+}
+
+// WaitDisconnect waits until the connected device is disconnected. It returns
+// false if timeout occurs. The negative timeout means no timeout.
+func (h *Host) WaitDisconnect(timeout time.Duration) bool {
+	for {
+		h.conn.Clear() // memory barrier
+		if h.speed == Disconnected {
+			return true
+		}
+		if !h.conn.Sleep(timeout) {
+			return h.speed == Disconnected
+		}
+	}
+}
+
+// Enumerate resets the bus, assigns an address to the connected device and
+// reads its device and configuration descriptors. The device remains
+// unconfigured (see HostDevice.SetConfiguration).
+func (h *Host) Enumerate() (*HostDevice, error) {
+	p := h.p
+	if h.speed == Disconnected {
+		return nil, ErrDisconnected
+	}
+	internal.AtomicSet(&p.SIE_CTRL, RESET_BUS)
+	time.Sleep(60 * time.Millisecond) // bus reset and reset recovery
+	if h.speed == Disconnected {
+		return nil, ErrDisconnected
+	}
+	d := &HostDevice{h: h, epoch: h.epoch, maxPkt0: 8}
+	var desc [18]byte
+	req := Setup{DirIn, GetDescriptor, DescDevice << 8, 0, 8}
+	if _, err := d.Control(&req, desc[:8]); err != nil {
+		return nil, err
+	}
+	d.maxPkt0 = uint16(desc[7])
+	if d.maxPkt0 != 8 && d.maxPkt0 != 16 && d.maxPkt0 != 32 &&
+		d.maxPkt0 != 64 {
+		return nil, ErrProtocol
+	}
+	addr := h.addr%127 + 1
+	req = Setup{0, SetAddress, uint16(addr), 0, 0}
+	if _, err := d.Control(&req, nil); err != nil {
+		return nil, err
+	}
+	h.addr = addr
+	d.addr = addr
+	time.Sleep(2 * time.Millisecond) // SetAddress recovery interval
+	req = Setup{DirIn, GetDescriptor, DescDevice << 8, 0, 18}
+	if n, err := d.Control(&req, desc[:]); err != nil {
+		return nil, err
+	} else if n != 18 {
+		return nil, ErrProtocol
+	}
+	d.devDesc = desc
+	var hdr [9]byte
+	req = Setup{DirIn, GetDescriptor, DescConfig << 8, 0, 9}
+	if n, err := d.Control(&req, hdr[:]); err != nil {
+		return nil, err
+	} else if n != 9 {
+		return nil, ErrProtocol
+	}
+	cfg := make([]byte, int(hdr[2])|int(hdr[3])<<8)
+	req.Length = uint16(len(cfg))
+	n, err := d.Control(&req, cfg)
+	if err != nil {
+		return nil, err
+	}
+	d.cfgDesc = cfg[:n]
+	return d, nil
+}
+
+// epx performs a single packet transaction using the EPX endpoint. The
+// caller must hold h.mu.
+func (h *Host) epx(d *HostDevice, ep uint8, typ EPType, sie SIE_CTRL, bc BUFCTRL, timeout time.Duration) error {
+	p := h.p
+	dp := p.HostDPRAM()
+	if d.epoch != h.epoch || h.speed == Disconnected {
+		return ErrDisconnected
+	}
+	dp.EPXCTRL.Store(EP_EN | EP_INT_1BUF | EPCTRL(typ)<<EP_TYPEn | dpramFirst)
+	p.ADDR_ENDP.Store(uint32(d.addr) | uint32(ep&0xf)<<ENDPOINTn)
+	h.epxStat = epxBusy
+	h.epxNote.Clear() // memory barrier
+	if sie&SEND_SETUP == 0 {
+		arm(&dp.BUFCTRL[0][0], bc|BUF_LAST)
+	}
+	sie |= sieHost
+	p.SIE_CTRL.Store(sie)
+	internal.BusyWaitAtLeastCycles(12)
+	p.SIE_CTRL.Store(sie | START_TRANS)
+	if !h.epxNote.Sleep(timeout) {
+		p.SIE_CTRL.Store(sieHost | STOP_TRANS)
+		dp.BUFCTRL[0][0].Store(0)
+		if h.epxStat == epxBusy {
+			h.epxStat = epxIdle
+			return ErrTimeout
+		}
+	}
+	return statusErr(h.epxStat)
+}
+
+// statusErr converts the transaction status to error.
+func statusErr(status uint8) error {
+	switch status {
+	case epxDone:
+		return nil
+	case epxStall:
+		return ErrHalted
+	case epxNoResp:
+		return ErrNoResponse
+	case epxDisconn:
+		return ErrDisconnected
+	}
+	return ErrProtocol
+}
+
+// epxBuf returns the EPX data buffer.
+func epxBuf(n int) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(mmap.USB_DPRAM_BASE+dpramFirst)), n)
+}
+
+// HostDevice represents a device attached to the Host.
+type HostDevice struct {
+	h       *Host
+	epoch   uint32
+	addr    uint8
+	maxPkt0 uint16
+	devDesc [18]byte
+	cfgDesc []byte
+}
+
+// Host returns the host the device is attached to.
+func (d *HostDevice) Host() *Host {
+	return d.h
+}
+
+// Connected reports whether the device is still connected.
+func (d *HostDevice) Connected() bool {
+	return d.epoch == d.h.epoch && d.h.speed != Disconnected
+}
+
+// Addr returns the device address assigned during the enumeration.
+func (d *HostDevice) Addr() int {
+	return int(d.addr)
+}
+
+// DeviceDesc returns the device descriptor.
+func (d *HostDevice) DeviceDesc() []byte {
+	return d.devDesc[:]
+}
+
+// ConfigDesc returns the first configuration descriptor including all the
+// interface, endpoint and class specific descriptors.
+func (d *HostDevice) ConfigDesc() []byte {
+	return d.cfgDesc
+}
+
+// VendorID returns the vendor ID from the device descriptor.
+func (d *HostDevice) VendorID() uint16 {
+	return uint16(d.devDesc[8]) | uint16(d.devDesc[9])<<8
+}
+
+// ProductID returns the product ID from the device descriptor.
+func (d *HostDevice) ProductID() uint16 {
+	return uint16(d.devDesc[10]) | uint16(d.devDesc[11])<<8
+}
+
+const ctrlTimeout = 500 * time.Millisecond
+
+// Control performs the control transfer on the default control pipe. The data
+// stage (if req.Length != 0) transfers at most len(data) bytes in the
+// direction specified by req.RequestType. Control returns the number of bytes
+// transferred in the data stage.
+func (d *HostDevice) Control(req *Setup, data []byte) (n int, err error) {
+	h := d.h
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	dp := h.p.HostDPRAM()
+	dp.SETUP[0].Store(uint32(req.RequestType) | uint32(req.Request)<<8 |
+		uint32(req.Value)<<16)
+	dp.SETUP[1].Store(uint32(req.Index) | uint32(req.Length)<<16)
+	if err = h.epx(d, 0, Control, SEND_SETUP, 0, ctrlTimeout); err != nil {
+		return
+	}
+	in := req.RequestType&DirIn != 0
+	length := min(int(req.Length), len(data))
+	mp := int(d.maxPkt0)
+	pid := BUF_DATA1
+	for n < length {
+		m := min(length-n, mp)
+		if in {
+			err = h.epx(d, 0, Control, RECEIVE_DATA, BUFCTRL(mp)|pid, ctrlTimeout)
+			if err != nil {
+				return
+			}
+			k := int(dp.BUFCTRL[0][0].Load() & BUF_LEN)
+			n += copy(data[n:length], epxBuf(k))
+			if k < mp {
+				break
+			}
+		} else {
+			copy(epxBuf(m), data[n:])
+			err = h.epx(d, 0, Control, SEND_DATA, BUF_FULL|BUFCTRL(m)|pid, ctrlTimeout)
+			if err != nil {
+				return
+			}
+			n += m
+		}
+		pid ^= BUF_DATA1
+	}
+	// Status stage: zero-length packet in the opposite direction, DATA1.
+	sie := RECEIVE_DATA
+	bc := BUF_DATA1
+	if in {
+		sie = SEND_DATA
+		bc |= BUF_FULL
+	}
+	err = h.epx(d, 0, Control, sie, bc, ctrlTimeout)
+	return
+}
+
+// SetConfiguration selects the device configuration.
+func (d *HostDevice) SetConfiguration(config int) error {
+	req := Setup{0, SetConfiguration, uint16(config), 0, 0}
+	_, err := d.Control(&req, nil)
+	return err
+}
+
+// ClearHalt clears the halt condition of the endpoint with address ep.
+func (d *HostDevice) ClearHalt(ep uint8) error {
+	req := Setup{RecipEP, ClearFeature, FeatureEPHalt, uint16(ep), 0}
+	_, err := d.Control(&req, nil)
+	return err
+}
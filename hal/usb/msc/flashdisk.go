@@ -0,0 +1,79 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msc
+
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/bootrom"
+)
+
+// FlashDisk is a BlockDevice that stores data in a region of the external QSPI
+// flash using 512 byte blocks. The writes that do not cover the whole 4 KiB
+// flash sector are performed using the read-modify-write cycle. The sectors
+// whose content doesn't change are not reprogrammed.
+//
+// The flash is written using the boot ROM functions (see bootrom.FlashErase
+// for the restrictions).
+type FlashDisk struct {
+	off    int
+	size   int
+	sector [bootrom.FlashSectorSize]byte
+}
+
+// NewFlashDisk returns a flash disk that occupies size bytes of the flash
+// starting at offset off. Both off and size must be multiples of 4096. Make
+// sure the region doesn't overlap the program image.
+func NewFlashDisk(off, size int) *FlashDisk {
+	if off < 0 || size <= 0 || (off|size)%bootrom.FlashSectorSize != 0 {
+		panic("msc: bad flash region")
+	}
+	return &FlashDisk{off: off, size: size}
+}
+
+func (d *FlashDisk) mem(off, n int) []byte {
+	addr := uintptr(bootrom.FlashBase + d.off + off)
+	return unsafe.Slice((*byte)(unsafe.Pointer(addr)), n)
+}
+
+// BlockSize implements the BlockDevice interface.
+func (d *FlashDisk) BlockSize() int { return 512 }
+
+// NumBlocks implements the BlockDevice interface.
+func (d *FlashDisk) NumBlocks() int64 { return int64(d.size / 512) }
+
+// ReadBlocks implements the BlockDevice interface.
+func (d *FlashDisk) ReadBlocks(lba int64, buf []byte) error {
+	copy(buf, d.mem(int(lba*512), len(buf)))
+	return nil
+}
+
+// WriteBlocks implements the BlockDevice interface.
+func (d *FlashDisk) WriteBlocks(lba int64, buf []byte) error {
+	const ss = bootrom.FlashSectorSize
+	off := int(lba * 512)
+	for len(buf) != 0 {
+		so := off &^ (ss - 1) // sector offset
+		n := min(so+ss-off, len(buf))
+		data := buf[:n]
+		if n != ss {
+			copy(d.sector[:], d.mem(so, ss))
+			copy(d.sector[off-so:], data)
+			data = d.sector[:]
+		}
+		if !bytes.Equal(data, d.mem(so, ss)) {
+			if err := bootrom.FlashErase(d.off+so, ss); err != nil {
+				return err
+			}
+			if err := bootrom.FlashProgram(d.off+so, data); err != nil {
+				return err
+			}
+		}
+		off += n
+		buf = buf[n:]
+	}
+	return nil
+}
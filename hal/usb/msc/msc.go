@@ -0,0 +1,263 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package msc implements the USB Mass Storage Class function (Bulk-Only
+// Transport, SCSI transparent command set).
+package msc
+
+import (
+	"embedded/rtos"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/usb"
+)
+
+// Class specific requests.
+const (
+	GetMaxLUN      = 0xfe
+	BulkOnlyReset  = 0xff
+	cbwSignature   = 0x43425355 // USBC
+	cswSignature   = 0x53425355 // USBS
+	cbwLen         = 31
+	cswLen         = 13
+	statusPassed   = 0
+	statusFailed   = 1
+	statusPhaseErr = 2
+)
+
+var errInvalidCBW = errors.New("msc: invalid CBW")
+
+// A BlockDevice is a storage device that can be read and written in blocks of
+// fixed size.
+type BlockDevice interface {
+	// BlockSize returns the size of the block in bytes (typically 512, at
+	// most 4096).
+	BlockSize() int
+
+	// NumBlocks returns the number of blocks available.
+	NumBlocks() int64
+
+	// ReadBlocks reads len(buf)/BlockSize() blocks starting from block lba.
+	ReadBlocks(lba int64, buf []byte) error
+
+	// WriteBlocks writes len(buf)/BlockSize() blocks starting from block lba.
+	WriteBlocks(lba int64, buf []byte) error
+}
+
+// A Syncer is a BlockDevice that buffers written data. Sync is called when the
+// host requests to synchronize the cache or ejects the medium.
+type Syncer interface {
+	Sync() error
+}
+
+// MSC implements the mass storage function that exposes a single block device
+// (LUN 0) to the host.
+type MSC struct {
+	name string
+	bd   BlockDevice
+	ro   bool
+
+	dev *usb.Device
+	ifc int
+	in  *usb.Endpoint
+	out *usb.Endpoint
+
+	reset    rtos.Note
+	resetReq bool
+
+	vendor  [8]byte
+	product [16]byte
+
+	sense sense
+	cbw   cbw
+	buf   [4096]byte
+}
+
+type sense struct {
+	key  uint8
+	asc  uint8
+	ascq uint8
+}
+
+type cbw struct {
+	tag    uint32
+	length uint32
+	in     bool
+	cb     [16]byte
+}
+
+// New returns a new mass storage function that exposes the block device bd.
+// The name is used as the interface string and as the SCSI product
+// identification. The block size of bd must not exceed 4096 bytes.
+func New(name string, bd BlockDevice) *MSC {
+	m := &MSC{name: name, bd: bd}
+	if bs := bd.BlockSize(); bs <= 0 || bs > len(m.buf) {
+		panic("msc: unsupported block size")
+	}
+	setID(m.vendor[:], "Embedded")
+	setID(m.product[:], name)
+	return m
+}
+
+func setID(dst []byte, s string) {
+	for i := range dst {
+		dst[i] = ' '
+	}
+	copy(dst, s)
+}
+
+// SetVendor sets the SCSI vendor identification (up to 8 ASCII characters).
+func (m *MSC) SetVendor(vendor string) {
+	setID(m.vendor[:], vendor)
+}
+
+// SetReadOnly sets the write protection of the medium.
+func (m *MSC) SetReadOnly(ro bool) {
+	m.ro = ro
+}
+
+// Init implements the usb.Function interface.
+func (m *MSC) Init(d *usb.Device) []byte {
+	m.dev = d
+	m.ifc = d.NewInterface()
+	m.out = d.NewEndpoint(usb.Bulk, false, 64)
+	m.in = d.NewEndpoint(usb.Bulk, true, 64)
+	str := int(d.NewString(m.name))
+	desc := usb.InterfaceDesc(m.ifc, 0, 2, 0x08, 0x06, 0x50, str)
+	desc = append(desc, usb.EndpointDesc(m.out, 0)...)
+	desc = append(desc, usb.EndpointDesc(m.in, 0)...)
+	return desc
+}
+
+// Control implements the usb.Function interface.
+//
+//go:nosplit
+func (m *MSC) Control(req *usb.Setup, data []byte) (n int, ok bool) {
+	if req.RequestType&usb.TypeMask != usb.Class || int(req.Index) != m.ifc {
+		return 0, false
+	}
+	switch req.Request {
+	case GetMaxLUN:
+		if len(data) == 0 {
+			return 0, false
+		}
+		data[0] = 0 // single LUN
+		return 1, true
+	case BulkOnlyReset:
+		m.resetReq = true
+		m.reset.Wakeup()
+		return 0, true
+	}
+	return 0, false
+}
+
+// SetConfig implements the usb.Function interface.
+//
+//go:nosplit
+func (m *MSC) SetConfig(config int) {
+	m.reset.Wakeup()
+}
+
+// Serve handles the commands sent by the host. It never returns so it is
+// usually run in a separate goroutine.
+func (m *MSC) Serve() {
+	for {
+		err := m.readCBW()
+		if err == usb.ErrNotConfigured {
+			m.dev.WaitConfigured(-1)
+			continue
+		}
+		if err != nil {
+			m.recover()
+			continue
+		}
+		residue, status := m.command()
+		var csw [cswLen]byte
+		le := binary.LittleEndian
+		le.PutUint32(csw[0:], cswSignature)
+		le.PutUint32(csw[4:], m.cbw.tag)
+		le.PutUint32(csw[8:], residue)
+		csw[12] = status
+		if m.in.WritePacket(csw[:]) == usb.ErrHalted {
+			m.waitUnstall(m.in)
+			m.in.WritePacket(csw[:])
+		}
+	}
+}
+
+// readCBW reads and validates the Command Block Wrapper.
+func (m *MSC) readCBW() error {
+	buf := m.buf[:64]
+	n, err := m.out.ReadPacket(buf)
+	if err != nil {
+		return err
+	}
+	le := binary.LittleEndian
+	cblen := int(buf[14] & 0x1f)
+	if n != cbwLen || le.Uint32(buf) != cbwSignature || cblen == 0 ||
+		cblen > 16 || buf[13]&0x0f != 0 {
+		return errInvalidCBW
+	}
+	m.cbw.tag = le.Uint32(buf[4:])
+	m.cbw.length = le.Uint32(buf[8:])
+	m.cbw.in = buf[12]&0x80 != 0
+	m.cbw.cb = [16]byte{}
+	copy(m.cbw.cb[:], buf[15:15+cblen])
+	return nil
+}
+
+// recover handles the invalid CBW. Both endpoints are halted until the host
+// performs the Reset Recovery (Bulk-Only Mass Storage Reset followed by
+// clearing the halt condition of both endpoints).
+func (m *MSC) recover() {
+	m.resetReq = false
+	m.in.SetStall(true)
+	m.out.SetStall(true)
+	for {
+		m.reset.Clear() // memory barrier
+		if m.resetReq || m.dev.Config() == 0 {
+			break
+		}
+		m.reset.Sleep(-1)
+	}
+	m.waitUnstall(m.in)
+	m.waitUnstall(m.out)
+}
+
+// waitUnstall waits until the host clears the halt condition of e.
+func (m *MSC) waitUnstall(e *usb.Endpoint) {
+	for e.Stalled() && m.dev.Config() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// sendData sends p to the host as a part of the data-in stage.
+func (m *MSC) sendData(p []byte) error {
+	mp := m.in.MaxPacket()
+	for len(p) != 0 {
+		n := min(len(p), mp)
+		if err := m.in.WritePacket(p[:n]); err != nil {
+			return err
+		}
+		p = p[n:]
+	}
+	return nil
+}
+
+// recvData receives len(p) bytes from the host as a part of the data-out
+// stage. The length of p must be a multiple of the maximum packet size.
+func (m *MSC) recvData(p []byte) (n int, err error) {
+	mp := m.out.MaxPacket()
+	for n < len(p) {
+		var k int
+		k, err = m.out.ReadPacket(p[n : n+mp])
+		n += k
+		if err != nil || k < mp {
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msc
+
+// RAMDisk is a BlockDevice that stores data in RAM using 512 byte blocks.
+type RAMDisk struct {
+	data []byte
+}
+
+// NewRAMDisk returns a RAM disk that uses data as its storage. The length of
+// data should be a multiple of 512, the remaining bytes are unused. The data
+// may contain a preformatted file system image.
+func NewRAMDisk(data []byte) *RAMDisk {
+	return &RAMDisk{data[:len(data)&^511]}
+}
+
+// BlockSize implements the BlockDevice interface.
+func (d *RAMDisk) BlockSize() int { return 512 }
+
+// NumBlocks implements the BlockDevice interface.
+func (d *RAMDisk) NumBlocks() int64 { return int64(len(d.data) / 512) }
+
+// ReadBlocks implements the BlockDevice interface.
+func (d *RAMDisk) ReadBlocks(lba int64, buf []byte) error {
+	copy(buf, d.data[lba*512:])
+	return nil
+}
+
+// WriteBlocks implements the BlockDevice interface.
+func (d *RAMDisk) WriteBlocks(lba int64, buf []byte) error {
+	copy(d.data[lba*512:], buf)
+	return nil
+}
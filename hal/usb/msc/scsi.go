@@ -0,0 +1,249 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package msc
+
+import "encoding/binary"
+
+// SCSI operation codes.
+const (
+	testUnitReady      = 0x00
+	requestSense       = 0x03
+	inquiry            = 0x12
+	modeSense6         = 0x1a
+	startStopUnit      = 0x1b
+	preventAllowRemove = 0x1e
+	readFormatCaps     = 0x23
+	readCapacity10     = 0x25
+	read10             = 0x28
+	write10            = 0x2a
+	verify10           = 0x2f
+	syncCache10        = 0x35
+	modeSense10        = 0x5a
+)
+
+// Sense keys with additional sense codes.
+var (
+	senseNone          = sense{0x00, 0x00, 0x00}
+	senseInvalidCmd    = sense{0x05, 0x20, 0x00}
+	senseInvalidField  = sense{0x05, 0x24, 0x00}
+	senseLBAOutOfRange = sense{0x05, 0x21, 0x00}
+	senseReadError     = sense{0x03, 0x11, 0x00}
+	senseWriteError    = sense{0x03, 0x0c, 0x00}
+	senseWriteProtect  = sense{0x07, 0x27, 0x00}
+)
+
+var be = binary.BigEndian
+
+// command executes the SCSI command from the last received CBW. It returns
+// the data residue and the command status for the CSW.
+func (m *MSC) command() (residue uint32, status uint8) {
+	cb := m.cbw.cb[:]
+	if cb[0] != requestSense {
+		m.sense = senseNone
+	}
+	buf := m.buf[:]
+	switch cb[0] {
+	case testUnitReady, preventAllowRemove, verify10:
+		return m.noData()
+	case startStopUnit, syncCache10:
+		if s, ok := m.bd.(Syncer); ok {
+			if s.Sync() != nil {
+				m.sense = senseWriteError
+				return m.failed()
+			}
+		}
+		return m.noData()
+	case requestSense:
+		clear(buf[:18])
+		buf[0] = 0x70 // current errors, fixed format
+		buf[2] = m.sense.key
+		buf[7] = 10
+		buf[12] = m.sense.asc
+		buf[13] = m.sense.ascq
+		m.sense = senseNone
+		return m.dataIn(buf[:min(18, int(cb[4]))])
+	case inquiry:
+		if cb[1]&1 != 0 {
+			m.sense = senseInvalidField // EVPD not supported
+			return m.failed()
+		}
+		clear(buf[:36])
+		buf[1] = 0x80 // removable medium
+		buf[2] = 0x04 // SPC-2
+		buf[3] = 0x02 // response data format
+		buf[4] = 36 - 5
+		copy(buf[8:16], m.vendor[:])
+		copy(buf[16:32], m.product[:])
+		copy(buf[32:36], "1.00")
+		return m.dataIn(buf[:min(36, int(be.Uint16(cb[3:])))])
+	case modeSense6:
+		buf[0], buf[1], buf[2], buf[3] = 3, 0, m.wp(), 0
+		return m.dataIn(buf[:min(4, int(cb[4]))])
+	case modeSense10:
+		clear(buf[:8])
+		buf[1] = 6
+		buf[3] = m.wp()
+		return m.dataIn(buf[:min(8, int(be.Uint16(cb[7:])))])
+	case readFormatCaps:
+		clear(buf[:12])
+		buf[3] = 8 // capacity list length
+		be.PutUint32(buf[4:], uint32(m.bd.NumBlocks()))
+		be.PutUint32(buf[8:], uint32(m.bd.BlockSize()))
+		buf[8] = 0x02 // formatted media
+		return m.dataIn(buf[:min(12, int(be.Uint16(cb[7:])))])
+	case readCapacity10:
+		be.PutUint32(buf[0:], uint32(m.bd.NumBlocks()-1))
+		be.PutUint32(buf[4:], uint32(m.bd.BlockSize()))
+		return m.dataIn(buf[:8])
+	case read10:
+		return m.read(int64(be.Uint32(cb[2:])), int(be.Uint16(cb[7:])))
+	case write10:
+		return m.write(int64(be.Uint32(cb[2:])), int(be.Uint16(cb[7:])))
+	}
+	m.sense = senseInvalidCmd
+	return m.failed()
+}
+
+// wp returns the device-specific parameter of the mode parameter header.
+func (m *MSC) wp() byte {
+	if m.ro {
+		return 0x80
+	}
+	return 0
+}
+
+// noData completes a command without the data stage.
+func (m *MSC) noData() (residue uint32, status uint8) {
+	if m.cbw.length != 0 {
+		residue, _ = m.failed()
+		return residue, statusPhaseErr
+	}
+	return 0, statusPassed
+}
+
+// failed completes the data stage expected by the host without transferring
+// any meaningful data.
+func (m *MSC) failed() (residue uint32, status uint8) {
+	c := &m.cbw
+	if c.length != 0 {
+		if c.in {
+			m.in.WritePacket(nil)
+		} else {
+			m.discard(c.length)
+		}
+	}
+	return c.length, statusFailed
+}
+
+// discard receives and discards n bytes sent by the host.
+func (m *MSC) discard(n uint32) {
+	for n != 0 {
+		k, err := m.recvData(m.buf[:min(n, uint32(len(m.buf)))])
+		if err != nil || k == 0 {
+			return
+		}
+		n -= uint32(k)
+	}
+}
+
+// dataIn sends p to the host and completes the data-in stage.
+func (m *MSC) dataIn(p []byte) (residue uint32, status uint8) {
+	c := &m.cbw
+	if !c.in {
+		residue, _ = m.failed()
+		return residue, statusPhaseErr
+	}
+	n := min(uint32(len(p)), c.length)
+	if m.sendData(p[:n]) != nil {
+		return c.length, statusFailed
+	}
+	return m.endIn(n), statusPassed
+}
+
+// endIn terminates the data-in stage after sending n bytes if the host
+// expects more data. It returns the data residue.
+func (m *MSC) endIn(n uint32) uint32 {
+	c := &m.cbw
+	if n < c.length && n%uint32(m.in.MaxPacket()) == 0 {
+		m.in.WritePacket(nil)
+	}
+	return c.length - n
+}
+
+func (m *MSC) checkRange(lba int64, cnt int) bool {
+	if lba+int64(cnt) > m.bd.NumBlocks() {
+		m.sense = senseLBAOutOfRange
+		return false
+	}
+	return true
+}
+
+func (m *MSC) read(lba int64, cnt int) (residue uint32, status uint8) {
+	c := &m.cbw
+	bs := m.bd.BlockSize()
+	if !c.in && c.length != 0 || uint32(cnt*bs) > c.length {
+		residue, _ = m.failed()
+		return residue, statusPhaseErr
+	}
+	if !m.checkRange(lba, cnt) {
+		return m.failed()
+	}
+	status = statusPassed
+	chunk := len(m.buf) / bs
+	sent := uint32(0)
+	for cnt != 0 {
+		nb := min(cnt, chunk)
+		buf := m.buf[:nb*bs]
+		if m.bd.ReadBlocks(lba, buf) != nil {
+			clear(buf)
+			m.sense = senseReadError
+			status = statusFailed
+		}
+		if m.sendData(buf) != nil {
+			return c.length, statusFailed
+		}
+		sent += uint32(len(buf))
+		lba += int64(nb)
+		cnt -= nb
+	}
+	return m.endIn(sent), status
+}
+
+func (m *MSC) write(lba int64, cnt int) (residue uint32, status uint8) {
+	c := &m.cbw
+	bs := m.bd.BlockSize()
+	if c.in && c.length != 0 || uint32(cnt*bs) > c.length {
+		residue, _ = m.failed()
+		return residue, statusPhaseErr
+	}
+	if m.ro {
+		m.sense = senseWriteProtect
+		return m.failed()
+	}
+	if !m.checkRange(lba, cnt) {
+		return m.failed()
+	}
+	status = statusPassed
+	chunk := len(m.buf) / bs
+	total := uint32(cnt * bs)
+	rcvd := uint32(0)
+	for cnt != 0 {
+		nb := min(cnt, chunk)
+		buf := m.buf[:nb*bs]
+		n, err := m.recvData(buf)
+		rcvd += uint32(n)
+		if err != nil || n != len(buf) {
+			return c.length - rcvd, statusFailed
+		}
+		if status == statusPassed && m.bd.WriteBlocks(lba, buf) != nil {
+			m.sense = senseWriteError
+			status = statusFailed
+		}
+		lba += int64(nb)
+		cnt -= nb
+	}
+	m.discard(c.length - total)
+	return c.length - total, status
+}
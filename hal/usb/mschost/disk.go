@@ -0,0 +1,382 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mschost implements the USB host side driver for the Mass Storage
+// Class devices (Bulk-Only Transport, SCSI transparent command set) like the
+// USB flash drives.
+package mschost
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/usb"
+	"github.com/embeddedgo/pico/hal/usb/msc"
+)
+
+var (
+	ErrNotMSC   = errors.New("mschost: not a bulk-only SCSI mass storage device")
+	ErrNotReady = errors.New("mschost: medium not ready")
+	ErrPhase    = errors.New("mschost: phase error")
+	ErrRange    = errors.New("mschost: block out of range")
+)
+
+// A SenseError is returned when the device reports a failed command. It
+// contains the sense data returned by the REQUEST SENSE command.
+type SenseError struct {
+	Key  uint8 // sense key
+	ASC  uint8 // additional sense code
+	ASCQ uint8 // additional sense code qualifier
+}
+
+func (e *SenseError) Error() string {
+	return "mschost: command failed (sense key " + strconv.Itoa(int(e.Key)) +
+		", ASC/ASCQ 0x" + strconv.FormatUint(uint64(e.ASC)<<8|uint64(e.ASCQ), 16) + ")"
+}
+
+// SCSI operation codes.
+const (
+	testUnitReady  = 0x00
+	requestSense   = 0x03
+	inquiry        = 0x12
+	readCapacity10 = 0x25
+	read10         = 0x28
+	write10        = 0x2a
+	syncCache10    = 0x35
+)
+
+const (
+	cbwSignature   = 0x43425355 // USBC
+	cswSignature   = 0x53425355 // USBS
+	cbwLen         = 31
+	cswLen         = 13
+	statusPassed   = 0
+	statusFailed   = 1
+	statusPhaseErr = 2
+)
+
+var be = binary.BigEndian
+
+// Disk is a driver for the logical unit 0 of an USB mass storage device. It
+// implements the msc.BlockDevice and msc.Syncer interfaces. A Disk can be used
+// by one goroutine at the same time.
+type Disk struct {
+	d   *usb.HostDevice
+	ifc uint16
+	in  *usb.Pipe
+	out *usb.Pipe
+	tag uint32
+
+	blockSize int
+	numBlocks int64
+	vendor    string
+	product   string
+
+	pkt [64]byte
+}
+
+// NewDisk finds the bulk-only SCSI interface of the device d, configures the
+// device and waits up to 5 seconds for the medium to become ready. It reads
+// the medium capacity so the Disk is ready to use.
+func NewDisk(d *usb.HostDevice) (*Disk, error) {
+	cfg := d.ConfigDesc()
+	if len(cfg) < 9 {
+		return nil, ErrNotMSC
+	}
+	var (
+		k      Disk
+		bot    bool
+		in     uint8
+		inPkt  int
+		out    uint8
+		outPkt int
+	)
+	for i := 0; i+2 <= len(cfg) && cfg[i] >= 2; i += int(cfg[i]) {
+		desc := cfg[i:min(i+int(cfg[i]), len(cfg))]
+		switch desc[1] {
+		case usb.DescInterface:
+			if len(desc) < 9 {
+				return nil, ErrNotMSC
+			}
+			// Mass storage class, SCSI transparent subclass, BBB protocol
+			bot = desc[5] == 8 && desc[6] == 6 && desc[7] == 0x50
+			in, out = 0, 0
+			if bot {
+				k.ifc = uint16(desc[2])
+			}
+		case usb.DescEndpoint:
+			if !bot || len(desc) < 7 || desc[3]&3 != 2 {
+				continue
+			}
+			maxPkt := int(desc[4]) | int(desc[5])<<8
+			if desc[2]&0x80 != 0 {
+				if in == 0 {
+					in, inPkt = desc[2], maxPkt
+				}
+			} else if out == 0 {
+				out, outPkt = desc[2], maxPkt
+			}
+		}
+		if in != 0 && out != 0 {
+			break
+		}
+	}
+	if in == 0 || out == 0 {
+		return nil, ErrNotMSC
+	}
+	if err := d.SetConfiguration(int(cfg[5])); err != nil {
+		return nil, err
+	}
+	var err error
+	if k.in, err = d.NewPipe(in, usb.Bulk, min(inPkt, 64), 0); err != nil {
+		return nil, err
+	}
+	if k.out, err = d.NewPipe(out, usb.Bulk, min(outPkt, 64), 0); err != nil {
+		return nil, err
+	}
+	k.in.SetTimeout(5 * time.Second)
+	k.out.SetTimeout(5 * time.Second)
+	k.d = d
+
+	// The devices with one LUN may stall the GetMaxLUN request. Only LUN 0 is
+	// used anyway.
+	var maxLUN [1]byte
+	req := usb.Setup{
+		RequestType: usb.DirIn | usb.Class | usb.RecipIfc,
+		Request:     msc.GetMaxLUN,
+		Index:       k.ifc,
+		Length:      1,
+	}
+	d.Control(&req, maxLUN[:])
+
+	var buf [36]byte
+	if _, err := k.command([]byte{inquiry, 0, 0, 0, 36, 0}, buf[:], true); err != nil {
+		return nil, err
+	}
+	if buf[0]&0x1f != 0 {
+		return nil, ErrNotMSC // not a direct access block device
+	}
+	k.vendor = strings.TrimSpace(string(buf[8:16]))
+	k.product = strings.TrimSpace(string(buf[16:32]))
+
+	// Some drives report the unit attention or not ready status for a while
+	// after attach.
+	for i := 0; ; i++ {
+		_, err := k.command([]byte{testUnitReady, 0, 0, 0, 0, 0}, nil, false)
+		if err == nil {
+			break
+		}
+		if _, ok := err.(*SenseError); !ok {
+			return nil, err
+		}
+		if i == 50 {
+			return nil, ErrNotReady
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	cdb := [10]byte{readCapacity10}
+	if _, err := k.command(cdb[:], buf[:8], true); err != nil {
+		return nil, err
+	}
+	k.numBlocks = int64(be.Uint32(buf[0:4])) + 1
+	k.blockSize = int(be.Uint32(buf[4:8]))
+	if k.blockSize == 0 || k.blockSize > 4096 {
+		return nil, ErrNotMSC
+	}
+	return &k, nil
+}
+
+// Vendor returns the vendor identification reported by the device.
+func (k *Disk) Vendor() string {
+	return k.vendor
+}
+
+// Product returns the product identification reported by the device.
+func (k *Disk) Product() string {
+	return k.product
+}
+
+// BlockSize returns the size of the block in bytes.
+func (k *Disk) BlockSize() int {
+	return k.blockSize
+}
+
+// NumBlocks returns the number of blocks on the medium.
+func (k *Disk) NumBlocks() int64 {
+	return k.numBlocks
+}
+
+// ReadBlocks reads len(buf)/BlockSize() blocks starting from block lba.
+func (k *Disk) ReadBlocks(lba int64, buf []byte) error {
+	return k.rw(read10, lba, buf)
+}
+
+// WriteBlocks writes len(buf)/BlockSize() blocks starting from block lba.
+func (k *Disk) WriteBlocks(lba int64, buf []byte) error {
+	return k.rw(write10, lba, buf)
+}
+
+// Sync writes the data cached by the device to the medium.
+func (k *Disk) Sync() error {
+	cdb := [10]byte{syncCache10}
+	_, err := k.command(cdb[:], nil, false)
+	return err
+}
+
+func (k *Disk) rw(op byte, lba int64, buf []byte) error {
+	cnt := len(buf) / k.blockSize
+	if lba < 0 || lba+int64(cnt) > k.numBlocks {
+		return ErrRange
+	}
+	buf = buf[:cnt*k.blockSize]
+	for len(buf) != 0 {
+		n := min(cnt, 0xffff)
+		cdb := [10]byte{op}
+		be.PutUint32(cdb[2:], uint32(lba))
+		be.PutUint16(cdb[7:], uint16(n))
+		m, err := k.command(cdb[:], buf[:n*k.blockSize], op == read10)
+		if err != nil {
+			return err
+		}
+		if m != n*k.blockSize {
+			return usb.ErrProtocol
+		}
+		buf = buf[m:]
+		lba += int64(n)
+		cnt -= n
+	}
+	return nil
+}
+
+// command executes the SCSI command cdb using the Bulk-Only Transport. The
+// data stage transfers len(data) bytes in the direction specified by in. The
+// command returns the number of bytes transferred in the data stage. If the
+// device reports the failed command the returned error is *SenseError.
+func (k *Disk) command(cdb, data []byte, in bool) (n int, err error) {
+	n, status, err := k.transport(cdb, data, in)
+	if err != nil {
+		return n, err
+	}
+	switch status {
+	case statusPassed:
+		return n, nil
+	case statusFailed:
+		var sd [18]byte
+		_, status, err = k.transport([]byte{requestSense, 0, 0, 0, 18, 0}, sd[:], true)
+		if err != nil {
+			return n, err
+		}
+		if status != statusPassed {
+			return n, &SenseError{}
+		}
+		return n, &SenseError{sd[2] & 0xf, sd[12], sd[13]}
+	}
+	k.resetRecovery()
+	return n, ErrPhase
+}
+
+// transport performs the command, data and status stages of the Bulk-Only
+// Transport. It returns the number of bytes transferred in the data stage and
+// the status from the CSW.
+func (k *Disk) transport(cdb, data []byte, in bool) (n int, status uint8, err error) {
+	k.tag++
+	cbw := k.pkt[:cbwLen]
+	clear(cbw)
+	le := binary.LittleEndian
+	le.PutUint32(cbw[0:], cbwSignature)
+	le.PutUint32(cbw[4:], k.tag)
+	le.PutUint32(cbw[8:], uint32(len(data)))
+	if in {
+		cbw[12] = 0x80
+	}
+	cbw[13] = 0 // LUN
+	cbw[14] = byte(len(cdb))
+	copy(cbw[15:], cdb)
+	if err = k.out.WritePacket(cbw); err != nil {
+		if err == usb.ErrHalted {
+			k.resetRecovery()
+		}
+		return
+	}
+
+	// Data stage. The device stalls the endpoint if it has less data than
+	// requested or it can't accept more.
+	pipe := k.out
+	if in {
+		pipe = k.in
+	}
+	for n < len(data) && err == nil {
+		var m int
+		if in {
+			m, err = k.in.ReadPacket(k.pkt[:])
+			n += copy(data[n:], k.pkt[:m])
+			if m < k.in.MaxPacket() {
+				break // short packet
+			}
+		} else {
+			m = min(len(data)-n, k.out.MaxPacket())
+			if err = k.out.WritePacket(data[n : n+m]); err == nil {
+				n += m
+			}
+		}
+	}
+	if err == usb.ErrHalted {
+		if err = k.clearHalt(pipe); err != nil {
+			return
+		}
+	} else if err != nil {
+		if err != usb.ErrDisconnected {
+			k.resetRecovery()
+		}
+		return
+	}
+
+	// Status stage. Retry once if the IN endpoint is halted.
+	var m int
+	for i := 0; ; i++ {
+		m, err = k.in.ReadPacket(k.pkt[:])
+		if err != usb.ErrHalted || i == 1 {
+			break
+		}
+		if err = k.clearHalt(k.in); err != nil {
+			return
+		}
+	}
+	if err != nil {
+		if err == usb.ErrHalted {
+			k.resetRecovery()
+		}
+		return
+	}
+	csw := k.pkt[:m]
+	if m != cswLen || le.Uint32(csw[0:]) != cswSignature ||
+		le.Uint32(csw[4:]) != k.tag || csw[12] > statusPhaseErr {
+		k.resetRecovery()
+		return n, statusPhaseErr, ErrPhase
+	}
+	return n, csw[12], nil
+}
+
+// clearHalt clears the halt condition of the endpoint of pipe.
+func (k *Disk) clearHalt(pipe *usb.Pipe) error {
+	err := k.d.ClearHalt(pipe.Addr())
+	pipe.ResetToggle()
+	return err
+}
+
+// resetRecovery performs the reset recovery described in the Bulk-Only
+// Transport specification (section 5.3.4).
+func (k *Disk) resetRecovery() {
+	req := usb.Setup{
+		RequestType: usb.Class | usb.RecipIfc,
+		Request:     msc.BulkOnlyReset,
+		Index:       k.ifc,
+	}
+	k.d.Control(&req, nil)
+	k.clearHalt(k.in)
+	k.clearHalt(k.out)
+}
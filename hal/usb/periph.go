@@ -0,0 +1,107 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+	"github.com/embeddedgo/pico/p/resets"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	ADDR_ENDP              mmio.U32
+	ADDR_ENDPx             [15]mmio.R32[ADDR_ENDP]
+	MAIN_CTRL              mmio.R32[MAIN_CTRL]
+	SOF_WR                 mmio.U32
+	SOF_RD                 mmio.U32
+	SIE_CTRL               mmio.R32[SIE_CTRL]
+	SIE_STATUS             mmio.R32[SIE_STATUS]
+	INT_EP_CTRL            mmio.U32
+	BUFF_STATUS            mmio.U32
+	BUFF_CPU_SHOULD_HANDLE mmio.U32
+	EP_ABORT               mmio.U32
+	EP_ABORT_DONE          mmio.U32
+	EP_STALL_ARM           mmio.U32
+	NAK_POLL               mmio.U32
+	EP_STATUS_STALL_NAK    mmio.U32
+	USB_MUXING             mmio.R32[USB_MUXING]
+	USB_PWR                mmio.R32[USB_PWR]
+	USBPHY_DIRECT          mmio.U32
+	USBPHY_DIRECT_OVERRIDE mmio.U32
+	USBPHY_TRIM            mmio.U32
+	LINESTATE_TUNING       mmio.U32
+	INTR                   mmio.R32[INTR]
+	INTE                   mmio.R32[INTR]
+	INTF                   mmio.R32[INTR]
+	INTS                   mmio.R32[INTR]
+	_                      [25]uint32
+	SOF_TIMESTAMP_RAW      mmio.U32
+	SOF_TIMESTAMP_LAST     mmio.U32
+	SM_STATE               mmio.U32
+	EP_TX_ERROR            mmio.U32
+	EP_RX_ERROR            mmio.U32
+	DEV_SM_WATCHDOG        mmio.U32
+}
+
+// USB returns the USB controller.
+func USB(n int) *Periph {
+	if n != 0 {
+		panic("wrong USB number")
+	}
+	return (*Periph)(unsafe.Pointer(mmap.USB_BASE))
+}
+
+// SetReset allows to assert/deassert the reset signal to the USB controller.
+func (p *Periph) SetReset(assert bool) {
+	internal.SetReset(resets.USBCTRL, assert)
+}
+
+// DPRAM represents the dual-port RAM shared by the CPU and the USB controller.
+// The layout below is valid in the device mode. The remaining space after the
+// control registers is used for the endpoint buffers.
+type DPRAM struct {
+	_ structs.HostLayout
+
+	SETUP   [2]mmio.U32              // SETUP packet received on EP0
+	EPCTRL  [15][2]mmio.R32[EPCTRL]  // endpoint control for EP1..EP15 (IN, OUT)
+	BUFCTRL [16][2]mmio.R32[BUFCTRL] // buffer control for EP0..EP15 (IN, OUT)
+	_       [dpramSize - 0x100]byte  // EP0 buffers and endpoint buffers
+}
+
+const (
+	dpramSize  = 4096
+	ep0Buf     = 0x100 // EP0 buffer (shared by IN and OUT)
+	dpramFirst = 0x180 // the first free byte for endpoint buffers
+)
+
+// DPRAM returns the DPRAM of the USB controller.
+func (p *Periph) DPRAM() *DPRAM {
+	return (*DPRAM)(unsafe.Pointer(mmap.USB_DPRAM_BASE))
+}
+
+// HostDPRAM represents the layout of the DPRAM in the host mode. All
+// transfers except the interrupt ones are performed using the single EPX
+// endpoint. The interrupt endpoints 1..15 are polled by the hardware.
+type HostDPRAM struct {
+	_ structs.HostLayout
+
+	SETUP   [2]mmio.U32              // SETUP packet to send
+	INTCTRL [15][2]mmio.R32[EPCTRL]  // control for interrupt EP1..EP15 ([n][0])
+	BUFCTRL [16][2]mmio.R32[BUFCTRL] // buffer control for EPX and EP1..EP15 ([n][0])
+	EPXCTRL mmio.R32[EPCTRL]         // EPX control
+	_       [dpramSize - 0x104]byte  // buffers
+}
+
+// HostDPRAM returns the DPRAM of the USB controller using the host mode
+// layout.
+func (p *Periph) HostDPRAM() *HostDPRAM {
+	return (*HostDPRAM)(unsafe.Pointer(mmap.USB_DPRAM_BASE))
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+import (
+	"embedded/rtos"
+	"time"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+// A Pipe represents a communication channel between the host and an endpoint
+// of the attached device. The interrupt pipes are polled by the hardware with
+// the interval specified in the endpoint descriptor. The bulk pipes share the
+// EPX endpoint with the control transfers so a bulk transfer that isn't
+// completed by the device (NAKed) blocks other transfers until it times out.
+// A pipe can be used by one goroutine at the same time.
+type Pipe struct {
+	d       *HostDevice
+	ep      uint8 // endpoint address
+	typ     EPType
+	maxPkt  uint16
+	slot    int8 // interrupt endpoint slot or -1 for EPX
+	pid     BUFCTRL
+	timeout time.Duration
+	done    rtos.Note
+	stat    uint8 // interrupt pipe transaction status
+}
+
+// NewPipe returns a new pipe to the endpoint ep (endpoint address as in the
+// endpoint descriptor) of type typ (Bulk or Interrupt). The interval is the
+// polling interval of the interrupt endpoint in milliseconds. There are 15
+// interrupt pipes available.
+func (d *HostDevice) NewPipe(ep uint8, typ EPType, maxPkt, interval int) (*Pipe, error) {
+	if typ != Bulk && typ != Interrupt || maxPkt <= 0 || maxPkt > 64 {
+		panic("usb: bad pipe")
+	}
+	pp := &Pipe{d: d, ep: ep, typ: typ, maxPkt: uint16(maxPkt), slot: -1,
+		timeout: -1}
+	if typ == Bulk {
+		return pp, nil
+	}
+	h := d.h
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, x := range h.pipes {
+		if x == nil {
+			pp.slot = int8(i)
+			break
+		}
+	}
+	if pp.slot < 0 {
+		return nil, ErrNoPipe
+	}
+	interval = min(max(interval, 1), 1024)
+	p := h.p
+	n := int(pp.slot)
+	ae := ADDR_ENDP(d.addr) | ADDR_ENDP(ep&0xf)<<ENDPOINTn
+	if ep&0x80 == 0 {
+		ae |= INTEP_DIR
+	}
+	p.ADDR_ENDPx[n].Store(ae)
+	p.HostDPRAM().INTCTRL[n][0].Store(EP_EN | EP_INT_1BUF | EP_INTERRUPT |
+		EPCTRL(interval-1)<<EP_INTERVALn | EPCTRL(pp.buf()))
+	h.pipes[n] = pp
+	internal.AtomicSetU32(&p.INT_EP_CTRL, 1<<(n+1))
+	return pp, nil
+}
+
+// Close releases the resources used by the pipe.
+func (pp *Pipe) Close() {
+	if pp.slot < 0 {
+		return
+	}
+	h := pp.d.h
+	h.mu.Lock()
+	n := int(pp.slot)
+	internal.AtomicClearU32(&h.p.INT_EP_CTRL, 1<<(n+1))
+	h.p.HostDPRAM().INTCTRL[n][0].Store(0)
+	h.pipes[n] = nil
+	pp.slot = -1
+	h.mu.Unlock()
+}
+
+// buf returns the offset of the pipe buffer in DPRAM.
+func (pp *Pipe) buf() uintptr {
+	return dpramFirst + 64*(uintptr(pp.slot)+1)
+}
+
+// Addr returns the endpoint address.
+func (pp *Pipe) Addr() uint8 {
+	return pp.ep
+}
+
+// MaxPacket returns the maximum packet size.
+func (pp *Pipe) MaxPacket() int {
+	return int(pp.maxPkt)
+}
+
+// SetTimeout sets the timeout for the ReadPacket and WritePacket methods. The
+// negative timeout (default) means no timeout.
+func (pp *Pipe) SetTimeout(timeout time.Duration) {
+	pp.timeout = timeout
+}
+
+// ResetToggle resets the data toggle to DATA0. Use it after clearing the
+// endpoint halt condition (see HostDevice.ClearHalt).
+func (pp *Pipe) ResetToggle() {
+	pp.pid = 0
+}
+
+// ReadPacket receives a single packet from the device. The length of p should
+// be at least MaxPacket, otherwise the excess data is lost.
+func (pp *Pipe) ReadPacket(p []byte) (n int, err error) {
+	if pp.ep&0x80 == 0 {
+		panic("usb: read from OUT pipe")
+	}
+	if pp.slot >= 0 {
+		if err = pp.intTransfer(BUFCTRL(pp.maxPkt)); err != nil {
+			return
+		}
+		bc := &pp.d.h.p.HostDPRAM().BUFCTRL[pp.slot+1][0]
+		n = min(int(bc.Load()&BUF_LEN), len(p))
+		addr := mmap.USB_DPRAM_BASE + pp.buf()
+		copy(p, unsafe.Slice((*byte)(unsafe.Pointer(addr)), n))
+		return
+	}
+	h := pp.d.h
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err = h.epx(pp.d, pp.ep, pp.typ, RECEIVE_DATA, BUFCTRL(pp.maxPkt)|pp.pid, pp.timeout)
+	if err != nil {
+		return
+	}
+	pp.pid ^= BUF_DATA1
+	n = min(int(h.p.HostDPRAM().BUFCTRL[0][0].Load()&BUF_LEN), len(p))
+	copy(p, epxBuf(n))
+	return
+}
+
+// WritePacket sends a single packet to the device. The length of p must not
+// exceed MaxPacket.
+func (pp *Pipe) WritePacket(p []byte) error {
+	if pp.ep&0x80 != 0 {
+		panic("usb: write to IN pipe")
+	}
+	if len(p) > int(pp.maxPkt) {
+		panic("usb: packet too long")
+	}
+	if pp.slot >= 0 {
+		addr := mmap.USB_DPRAM_BASE + pp.buf()
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(addr)), len(p)), p)
+		return pp.intTransfer(BUF_FULL | BUFCTRL(len(p)))
+	}
+	h := pp.d.h
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	copy(epxBuf(len(p)), p)
+	err := h.epx(pp.d, pp.ep, pp.typ, SEND_DATA, BUF_FULL|BUFCTRL(len(p))|pp.pid, pp.timeout)
+	if err == nil {
+		pp.pid ^= BUF_DATA1
+	}
+	return err
+}
+
+// intTransfer performs a single transaction on the interrupt endpoint polled
+// by the hardware.
+func (pp *Pipe) intTransfer(v BUFCTRL) error {
+	d := pp.d
+	h := d.h
+	if !d.Connected() {
+		return ErrDisconnected
+	}
+	n := int(pp.slot)
+	bc := &h.p.HostDPRAM().BUFCTRL[n+1][0]
+	pp.stat = epxBusy
+	pp.done.Clear() // memory barrier
+	arm(bc, v|pp.pid|BUF_LAST)
+	if !pp.done.Sleep(pp.timeout) || pp.stat != epxDone {
+		// Disarm the buffer so the endpoint isn't polled any more.
+		mask := uint32(1) << (n + 1)
+		internal.AtomicClearU32(&h.p.INT_EP_CTRL, mask)
+		bc.Store(bc.Load() &^ BUF_AVAIL)
+		internal.AtomicSetU32(&h.p.INT_EP_CTRL, mask)
+		if pp.stat == epxBusy {
+			pp.stat = epxIdle
+			return ErrTimeout
+		}
+	}
+	if err := statusErr(pp.stat); err != nil {
+		return err
+	}
+	pp.pid ^= BUF_DATA1
+	return nil
+}
+
+// end ends the transaction in progress with the status.
+//
+//go:nosplit
+func (pp *Pipe) end(status uint8) {
+	if pp.stat == epxBusy {
+		pp.stat = status
+		pp.done.Wakeup()
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb
+
+// Setup represents the SETUP packet of a control transfer.
+type Setup struct {
+	RequestType uint8
+	Request     uint8
+	Value       uint16
+	Index       uint16
+	Length      uint16
+}
+
+// RequestType bits.
+const (
+	DirIn     = 0x80 // device to host data stage
+	TypeMask  = 0x60
+	Standard  = 0x00
+	Class     = 0x20
+	Vendor    = 0x40
+	RecipMask = 0x1f
+	RecipDev  = 0x00
+	RecipIfc  = 0x01
+	RecipEP   = 0x02
+)
+
+// Standard requests.
+const (
+	GetStatus        = 0
+	ClearFeature     = 1
+	SetFeature       = 3
+	SetAddress       = 5
+	GetDescriptor    = 6
+	SetDescriptor    = 7
+	GetConfiguration = 8
+	SetConfiguration = 9
+	GetInterface     = 10
+	SetInterface     = 11
+)
+
+// Descriptor types.
+const (
+	DescDevice       = 1
+	DescConfig       = 2
+	DescString       = 3
+	DescInterface    = 4
+	DescEndpoint     = 5
+	DescQualifier    = 6
+	DescIfaceAssoc   = 11
+	DescClassIface   = 0x24 // class-specific interface
+	DescClassEP      = 0x25 // class-specific endpoint
+	FeatureEPHalt    = 0
+	FeatureRemoteWkp = 1
+)
+
+// EPType represents the endpoint transfer type as used in the endpoint
+// descriptor (bmAttributes).
+type EPType uint8
+
+const (
+	Control     EPType = 0
+	Isochronous EPType = 1
+	Bulk        EPType = 2
+	Interrupt   EPType = 3
+)
+
+// InterfaceDesc returns the interface descriptor.
+func InterfaceDesc(num, alt, numEP, class, subclass, protocol, str int) []byte {
+	return []byte{
+		9, DescInterface, byte(num), byte(alt), byte(numEP),
+		byte(class), byte(subclass), byte(protocol), byte(str),
+	}
+}
+
+// EndpointDesc returns the descriptor of the endpoint e. The interval is used
+// only by the interrupt and isochronous endpoints (in frames).
+func EndpointDesc(e *Endpoint, interval int) []byte {
+	return []byte{
+		7, DescEndpoint, e.Addr(), byte(e.typ),
+		byte(e.maxPkt), byte(e.maxPkt >> 8), byte(interval),
+	}
+}
+
+// IfaceAssocDesc returns the interface association descriptor that groups
+// count interfaces starting from first into a single function.
+func IfaceAssocDesc(first, count, class, subclass, protocol, str int) []byte {
+	return []byte{
+		8, DescIfaceAssoc, byte(first), byte(count),
+		byte(class), byte(subclass), byte(protocol), byte(str),
+	}
+}
+
+func stringDesc(s string) []byte {
+	n := 0
+	for range s {
+		n++
+	}
+	d := make([]byte, 2, 2+2*n)
+	for _, r := range s {
+		if r > 0xffff {
+			r = '?'
+		}
+		d = append(d, byte(r), byte(r>>8))
+	}
+	d[0] = byte(len(d))
+	d[1] = DescString
+	return d
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package usb0
+
+import (
+	"embedded/rtos"
+	_ "unsafe"
+
+	"github.com/embeddedgo/pico/hal/irq"
+	"github.com/embeddedgo/pico/hal/system"
+	"github.com/embeddedgo/pico/hal/usb"
+)
+
+var (
+	device *usb.Device
+	host   *usb.Host
+)
+
+// Device returns the driver for the USB controller working in the device mode.
+// It must be set up before use (see usb.Device.Setup). Device and Host cannot
+// be used both in the same program.
+func Device() *usb.Device {
+	if device == nil {
+		if host != nil {
+			panic("usb0: used in host mode")
+		}
+		device = usb.NewDevice(usb.USB(0))
+		irq.USBCTRL.Enable(rtos.IntPrioLow, system.NextCPU())
+	}
+	return device
+}
+
+// Host returns the driver for the USB controller working in the host mode. It
+// must be set up before use (see usb.Host.Setup).
+func Host() *usb.Host {
+	if host == nil {
+		if device != nil {
+			panic("usb0: used in device mode")
+		}
+		host = usb.NewHost(usb.USB(0))
+		irq.USBCTRL.Enable(rtos.IntPrioLow, system.NextCPU())
+	}
+	return host
+}
+
+//go:interrupthandler
+func _USBCTRL_Handler() {
+	if device != nil {
+		device.ISR()
+	} else {
+		host.ISR()
+	}
+}
+
+//go:linkname _USBCTRL_Handler IRQ14_Handler
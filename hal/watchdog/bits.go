@@ -0,0 +1,44 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watchdog
+
+type CTRL uint32
+
+const (
+	TIME       CTRL = 0xFFFFFF << 0 //+ Time in µs before a watchdog reset.
+	PAUSE_JTAG CTRL = 0x01 << 24    //+ Pause when JTAG is accessing the bus fabric.
+	PAUSE_DBG0 CTRL = 0x01 << 25    //+ Pause when processor 0 is in debug mode.
+	PAUSE_DBG1 CTRL = 0x01 << 26    //+ Pause when processor 1 is in debug mode.
+	ENABLE     CTRL = 0x01 << 30    //+ When not enabled the watchdog timer is paused.
+	TRIGGER    CTRL = 0x01 << 31    //+ Trigger a watchdog reset.
+)
+
+const (
+	TIMEn       = 0
+	PAUSE_JTAGn = 24
+	PAUSE_DBG0n = 25
+	PAUSE_DBG1n = 26
+	ENABLEn     = 30
+	TRIGGERn    = 31
+)
+
+type REASON uint32
+
+const (
+	TIMER REASON = 0x01 << 0 //+ The last reset was caused by the watchdog timeout.
+	FORCE REASON = 0x01 << 1 //+ The last reset was forced by software (TRIGGER).
+)
+
+const (
+	TIMERn = 0
+	FORCEn = 1
+)
+
+// PSM WDSEL bits (the blocks reset by the watchdog).
+const (
+	wdselROSC = 1 << 2
+	wdselXOSC = 1 << 3
+	wdselAll  = 1<<25 - 1 // PROC_COLD to PROC1
+)
@@ -0,0 +1,26 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watchdog
+
+import "time"
+
+// StartFeeder starts a goroutine that feeds the watchdog every period as long
+// as the healthy function returns true. When healthy returns false the
+// goroutine stops feeding the watchdog and exits so the chip is reset when the
+// timeout set by Start expires. The healthy function is called from the
+// feeder goroutine before every feed so it should return quickly. If healthy
+// is nil the watchdog is fed unconditionally, which only protects against
+// the system wide failures (e.g. a high priority goroutine that never yields).
+func StartFeeder(period time.Duration, healthy func() bool) {
+	go func() {
+		for {
+			if healthy != nil && !healthy() {
+				return
+			}
+			Feed()
+			time.Sleep(period)
+		}
+	}()
+}
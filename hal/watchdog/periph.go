@@ -0,0 +1,32 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watchdog
+
+import (
+	"embedded/mmio"
+	"structs"
+	"unsafe"
+
+	"github.com/embeddedgo/pico/p/mmap"
+)
+
+type Periph struct {
+	_ structs.HostLayout
+
+	CTRL    mmio.R32[CTRL]
+	LOAD    mmio.U32
+	REASON  mmio.R32[REASON]
+	SCRATCH [8]mmio.U32
+}
+
+// WATCHDOG returns the watchdog peripheral.
+func WATCHDOG() *Periph {
+	return (*Periph)(unsafe.Pointer(mmap.WATCHDOG_BASE))
+}
+
+// psmWDSEL returns the PSM WDSEL register.
+func psmWDSEL() *mmio.U32 {
+	return (*mmio.U32)(unsafe.Pointer(mmap.PSM_BASE + 0x08))
+}
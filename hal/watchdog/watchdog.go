@@ -0,0 +1,175 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package watchdog provides interface to the watchdog timer.
+//
+// The watchdog resets the chip if it isn't fed before the configured timeout
+// expires. The reason of the last reset and the content of the scratch
+// registers can be read after the reset.
+package watchdog
+
+import (
+	"embedded/rtos"
+	"runtime"
+	"time"
+
+	"github.com/embeddedgo/pico/hal/internal"
+	"github.com/embeddedgo/pico/hal/system/clock"
+	"github.com/embeddedgo/pico/p/ticks"
+)
+
+// MaxTimeout is the longest timeout supported by the watchdog.
+const MaxTimeout = time.Duration(TIME) * time.Microsecond
+
+var load uint32
+
+// Start starts the watchdog with the given timeout (up to MaxTimeout). The
+// watchdog is paused when a debugger halts the CPUs.
+//
+// The watchdog registers are accessible only in the privileged mode. Start
+// allows also the unprivileged access to them so Feed can be called from any
+// goroutine without switching the privilege level.
+func Start(timeout time.Duration) {
+	us := timeout.Microseconds()
+	if us <= 0 || us > int64(TIME) {
+		panic("watchdog: bad timeout")
+	}
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+
+	// Configure the 1 MHz watchdog tick.
+	t := &ticks.TICKS().T[ticks.WATCHDOG]
+	t.CYCLES.Store(uint32(clock.REF.Freq()) / 1e6)
+	t.CTRL.Store(ticks.ENABLE)
+
+	internal.AccessGrant(internal.AccWATCHDOG, internal.AccSU)
+	wd := WATCHDOG()
+	internal.AtomicClear(&wd.CTRL, ENABLE)
+	setResetScope()
+	load = uint32(us)
+	wd.LOAD.Store(load)
+	internal.AtomicSet(&wd.CTRL, ENABLE|PAUSE_DBG0|PAUSE_DBG1|PAUSE_JTAG)
+
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Stop stops the watchdog. Stop is useful before entering a long operation
+// that can't feed the watchdog, e.g. the dormant mode.
+func Stop() {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	internal.AtomicClear(&WATCHDOG().CTRL, ENABLE)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
+
+// Feed reloads the watchdog timer with the timeout set by Start. It relies on
+// the unprivileged access allowed by Start.
+//
+//go:nosplit
+func Feed() {
+	WATCHDOG().LOAD.Store(load)
+}
+
+// Remaining returns the time remaining to the watchdog reset.
+func Remaining() time.Duration {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	us := WATCHDOG().CTRL.LoadBits(TIME)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return time.Duration(us) * time.Microsecond
+}
+
+// setResetScope makes the watchdog reset everything except the oscillators.
+// It must be called in the privileged mode.
+func setResetScope() {
+	psmWDSEL().Store(wdselAll &^ (wdselROSC | wdselXOSC))
+}
+
+// Reboot resets the chip immediately using the watchdog. Reason returns
+// Forced after such a reset.
+func Reboot() {
+	runtime.LockOSThread()
+	rtos.SetPrivLevel(0)
+	setResetScope()
+	internal.AtomicSet(&WATCHDOG().CTRL, TRIGGER)
+	for {
+	}
+}
+
+// A ResetReason describes the cause of the last chip reset.
+type ResetReason uint8
+
+const (
+	PowerOn ResetReason = iota // power-on, brownout or RUN pin reset
+	Timeout                    // the watchdog was not fed in time
+	Forced                     // reset forced by software (Reboot)
+)
+
+func (r ResetReason) String() string {
+	switch r {
+	case PowerOn:
+		return "power-on"
+	case Timeout:
+		return "watchdog timeout"
+	case Forced:
+		return "forced"
+	}
+	return "unknown"
+}
+
+// Reason returns the cause of the last chip reset.
+func Reason() ResetReason {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	r := WATCHDOG().REASON.Load()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	switch {
+	case r&FORCE != 0:
+		return Forced
+	case r&TIMER != 0:
+		return Timeout
+	}
+	return PowerOn
+}
+
+// A Scratch represents one of the eight watchdog scratch registers. Their
+// content survives the watchdog reset (but not the power-on reset) so they can
+// be used to pass information to the rebooted program. The boot ROM uses the
+// Scratch2 to Scratch7 registers to pass the parameters of the reboot
+// requested using the ROM reboot function.
+type Scratch uint8
+
+const (
+	Scratch0 Scratch = iota
+	Scratch1
+	Scratch2
+	Scratch3
+	Scratch4
+	Scratch5
+	Scratch6
+	Scratch7
+)
+
+// Load returns the content of the scratch register.
+func (s Scratch) Load() uint32 {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	v := WATCHDOG().SCRATCH[s].Load()
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+	return v
+}
+
+// Store stores v in the scratch register.
+func (s Scratch) Store(v uint32) {
+	runtime.LockOSThread()
+	pl, _ := rtos.SetPrivLevel(0)
+	WATCHDOG().SCRATCH[s].Store(v)
+	rtos.SetPrivLevel(pl)
+	runtime.UnlockOSThread()
+}
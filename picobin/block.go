@@ -0,0 +1,244 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package picobin implements the format of the metadata blocks that the RP2350
+// boot ROM looks for in a binary image (IMAGE_DEF, PARTITION_TABLE).
+//
+// A block is a list of items enclosed between the start and end markers. The
+// blocks in an image form a loop: every block contains the relative offset of
+// the next one and the last block links back to the first one, which must be
+// in the first 4 KiB of the image.
+//
+// The package doesn't depend on the RP2350 hardware so it can be used by the
+// host tools as well as by the programs running on the device.
+package picobin
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	BlockStart = 0xffffded3 // block start marker
+	BlockEnd   = 0xab123579 // block end marker
+
+	// The first block must start in the first FirstBlockMax bytes of image.
+	FirstBlockMax = 4096
+)
+
+// Item types (without the size flag).
+const (
+	ItemVectorTable    = 0x03
+	ItemRollingWindow  = 0x05
+	ItemLoadMap        = 0x06
+	ItemSignature      = 0x09
+	ItemPartitionTable = 0x0a
+	ItemImageType      = 0x42
+	ItemEntryPoint     = 0x44
+	ItemHashDef        = 0x47
+	ItemVersion        = 0x48
+	ItemHashValue      = 0x4b
+	ItemIgnored        = 0x7e
+	ItemLast           = 0x7f
+)
+
+var (
+	ErrNoBlock  = errors.New("picobin: no block found")
+	ErrBadBlock = errors.New("picobin: malformed block")
+	ErrBadLoop  = errors.New("picobin: broken block loop")
+)
+
+// Item is a block item. Its first word is the item header that contains the
+// item type in the bits 6:0, the size flag in bit 7 and the item size in
+// words (one or two bytes, depending on the size flag). The remaining header
+// bits are type specific.
+type Item []uint32
+
+// Type returns the item type without the size flag.
+func (it Item) Type() uint8 {
+	return uint8(it[0] & 0x7f)
+}
+
+// itemSize returns the size of the item in words decoded from its header h.
+func itemSize(h uint32) int {
+	if h&0x80 != 0 {
+		return int(h >> 8 & 0xffff)
+	}
+	return int(h >> 8 & 0xff)
+}
+
+// header returns the header of the item of type typ and size words. The type
+// specific bits must be ORed by caller.
+func header(typ uint8, size int) uint32 {
+	if size < 256 && typ != ItemIgnored && typ != ItemLast {
+		return uint32(typ) | uint32(size)<<8
+	}
+	return 0x80 | uint32(typ) | uint32(size)<<8
+}
+
+// Block represents a metadata block.
+type Block struct {
+	Items []Item
+	Link  int32 // offset of the next block relative to this one (bytes)
+}
+
+// Find returns the first item of type typ or nil if there is no such item.
+func (b *Block) Find(typ uint8) Item {
+	for _, it := range b.Items {
+		if it.Type() == typ {
+			return it
+		}
+	}
+	return nil
+}
+
+// Size returns the size of the encoded block in bytes.
+func (b *Block) Size() int {
+	n := 4 // start marker, LAST item, link, end marker
+	for _, it := range b.Items {
+		n += len(it)
+	}
+	return n * 4
+}
+
+// Words returns the encoded block.
+func (b *Block) Words() []uint32 {
+	w := make([]uint32, 0, b.Size()/4)
+	w = append(w, BlockStart)
+	for _, it := range b.Items {
+		w = append(w, it...)
+	}
+	w = append(w, header(ItemLast, len(w)-1), uint32(b.Link), BlockEnd)
+	return w
+}
+
+// Bytes returns the encoded block as a byte slice.
+func (b *Block) Bytes() []byte {
+	w := b.Words()
+	p := make([]byte, 0, len(w)*4)
+	for _, v := range w {
+		p = binary.LittleEndian.AppendUint32(p, v)
+	}
+	return p
+}
+
+// ParseBlock decodes the block that starts at the beginning of p.
+func ParseBlock(p []byte) (*Block, error) {
+	word := func(i int) (uint32, bool) {
+		if i*4+4 > len(p) {
+			return 0, false
+		}
+		return binary.LittleEndian.Uint32(p[i*4:]), true
+	}
+	if w, ok := word(0); !ok || w != BlockStart {
+		return nil, ErrBadBlock
+	}
+	b := new(Block)
+	i := 1
+	for {
+		h, ok := word(i)
+		if !ok {
+			return nil, ErrBadBlock
+		}
+		size := itemSize(h)
+		if uint8(h&0x7f) == ItemLast {
+			if size != i-1 {
+				return nil, ErrBadBlock
+			}
+			break
+		}
+		if size == 0 || (i+size)*4 > len(p) {
+			return nil, ErrBadBlock
+		}
+		it := make(Item, size)
+		for k := range it {
+			it[k], _ = word(i + k)
+		}
+		b.Items = append(b.Items, it)
+		i += size
+	}
+	link, ok := word(i + 1)
+	end, ok1 := word(i + 2)
+	if !ok || !ok1 || end != BlockEnd {
+		return nil, ErrBadBlock
+	}
+	b.Link = int32(link)
+	return b, nil
+}
+
+// Loop returns the blocks that form the block loop of img and their offsets.
+// The first block is looked for at the word aligned offsets in the first
+// FirstBlockMax bytes of img.
+func Loop(img []byte) (offs []int, blocks []*Block, err error) {
+	first := -1
+	var b *Block
+	for off := 0; off < FirstBlockMax && off+4 <= len(img); off += 4 {
+		if binary.LittleEndian.Uint32(img[off:]) != BlockStart {
+			continue
+		}
+		if b, err = ParseBlock(img[off:]); err == nil {
+			first = off
+			break
+		}
+	}
+	if first < 0 {
+		return nil, nil, ErrNoBlock
+	}
+	off := first
+	for {
+		offs = append(offs, off)
+		blocks = append(blocks, b)
+		off += int(b.Link)
+		if off == first {
+			return offs, blocks, nil
+		}
+		if off < 0 || off >= len(img) || off&3 != 0 || len(offs) > 64 {
+			return nil, nil, ErrBadLoop
+		}
+		if b, err = ParseBlock(img[off:]); err != nil {
+			return nil, nil, ErrBadLoop
+		}
+	}
+}
+
+// erased reports whether p contains only the erased flash bytes (0xff).
+func erased(p []byte) bool {
+	for _, b := range p {
+		if b != 0xff {
+			return false
+		}
+	}
+	return true
+}
+
+// ignoreImageDefs marks the IMAGE_DEF items in the blocks of img as ignored.
+func ignoreImageDefs(img []byte, offs []int, blocks []*Block) {
+	for k, b := range blocks {
+		off := offs[k] + 4
+		for _, it := range b.Items {
+			switch it.Type() {
+			case ItemImageType, ItemVersion, ItemVectorTable, ItemEntryPoint,
+				ItemRollingWindow, ItemLoadMap, ItemHashDef, ItemHashValue,
+				ItemSignature:
+				h := header(ItemIgnored, len(it))
+				binary.LittleEndian.PutUint32(img[off:], h)
+			}
+			off += len(it) * 4
+		}
+	}
+}
+
+// linkEnd pads img to the word boundary and links the last block of the loop
+// to the end of img, where the new block is to be appended. It returns the
+// padded img and the offset of the new block.
+func linkEnd(img []byte, offs []int, blocks []*Block) ([]byte, int) {
+	for len(img)&3 != 0 {
+		img = append(img, 0)
+	}
+	off := len(img)
+	last := len(blocks) - 1
+	linkOff := offs[last] + blocks[last].Size() - 8
+	binary.LittleEndian.PutUint32(img[linkOff:], uint32(off-offs[last]))
+	return img, off
+}
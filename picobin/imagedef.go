@@ -0,0 +1,145 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package picobin
+
+// ImageType is the content of the IMAGE_TYPE item flags.
+type ImageType uint16
+
+const (
+	TypeInvalid ImageType = 0 << 0
+	TypeExe     ImageType = 1 << 0 // executable image
+	TypeData    ImageType = 2 << 0 // data image
+	TypeMask    ImageType = 15 << 0
+
+	SecurityUnspecified ImageType = 0 << 4
+	SecurityNS          ImageType = 1 << 4 // Non-secure executable
+	SecurityS           ImageType = 2 << 4 // Secure executable
+	SecurityMask        ImageType = 3 << 4
+
+	CPUArm   ImageType = 0 << 8
+	CPURISCV ImageType = 1 << 8
+	CPUMask  ImageType = 7 << 8
+
+	ChipRP2040 ImageType = 0 << 12
+	ChipRP2350 ImageType = 1 << 12
+	ChipMask   ImageType = 7 << 12
+
+	TBYB ImageType = 1 << 15 // try before you buy image
+)
+
+// DefaultImageType describes the Embedded Go program for RP2350.
+const DefaultImageType = TypeExe | SecurityS | CPUArm | ChipRP2350
+
+// ImageDef describes the items of the IMAGE_DEF block.
+type ImageDef struct {
+	Type ImageType
+
+	// Version (VERSION item, omitted if all fields are zero). The rollback
+	// version is checked by the boot ROM only if the secure boot is enabled.
+	// It's stored in the OTP rows RollbackRows as a thermometer code and
+	// ignored if RollbackRows is empty.
+	Major, Minor uint16
+	Rollback     uint16
+	RollbackRows []uint16
+
+	VectorTable   uint32 // VECTOR_TABLE item if not zero
+	EntryPoint    uint32 // ENTRY_POINT item if not zero
+	StackPointer  uint32 // initial SP for EntryPoint
+	RollingWindow int32  // ROLLING_WINDOW_DELTA item if not zero
+	LoadMap       []LoadMapEntry
+}
+
+// Items returns the items of the IMAGE_DEF block described by d.
+func (d *ImageDef) Items() []Item {
+	items := []Item{{header(ItemImageType, 1) | uint32(d.Type)<<16}}
+	if d.Major != 0 || d.Minor != 0 || len(d.RollbackRows) != 0 {
+		it := Item{0, uint32(d.Minor) | uint32(d.Major)<<16}
+		if n := len(d.RollbackRows); n != 0 {
+			hw := append([]uint16{d.Rollback}, d.RollbackRows...)
+			if len(hw)&1 != 0 {
+				hw = append(hw, 0)
+			}
+			for i := 0; i < len(hw); i += 2 {
+				it = append(it, uint32(hw[i])|uint32(hw[i+1])<<16)
+			}
+			it[0] = uint32(n) << 24
+		}
+		it[0] |= header(ItemVersion, len(it))
+		items = append(items, it)
+	}
+	if d.VectorTable != 0 {
+		items = append(items, Item{header(ItemVectorTable, 2), d.VectorTable})
+	}
+	if d.EntryPoint != 0 {
+		items = append(items, Item{header(ItemEntryPoint, 3), d.EntryPoint, d.StackPointer})
+	}
+	if d.RollingWindow != 0 {
+		items = append(items, Item{header(ItemRollingWindow, 2), uint32(d.RollingWindow)})
+	}
+	if len(d.LoadMap) != 0 {
+		items = append(items, LoadMapItem(d.LoadMap...))
+	}
+	return items
+}
+
+// ParseImageDef decodes the IMAGE_DEF items of b. It returns nil if b doesn't
+// contain the IMAGE_TYPE item.
+func ParseImageDef(b *Block) *ImageDef {
+	it := b.Find(ItemImageType)
+	if it == nil {
+		return nil
+	}
+	d := &ImageDef{Type: ImageType(it[0] >> 16)}
+	if it = b.Find(ItemVersion); len(it) >= 2 {
+		d.Minor, d.Major = uint16(it[1]), uint16(it[1]>>16)
+		var hw []uint16
+		for _, w := range it[2:] {
+			hw = append(hw, uint16(w), uint16(w>>16))
+		}
+		if n := int(it[0] >> 24); n != 0 && len(hw) >= n+1 {
+			d.Rollback = hw[0]
+			d.RollbackRows = hw[1 : n+1]
+		}
+	}
+	if it = b.Find(ItemVectorTable); len(it) >= 2 {
+		d.VectorTable = it[1]
+	}
+	if it = b.Find(ItemEntryPoint); len(it) >= 3 {
+		d.EntryPoint, d.StackPointer = it[1], it[2]
+	}
+	if it = b.Find(ItemRollingWindow); len(it) >= 2 {
+		d.RollingWindow = int32(it[1])
+	}
+	if it = b.Find(ItemLoadMap); it != nil && it[0]>>31 == 0 {
+		for e := it[1:]; len(e) >= 3; e = e[3:] {
+			d.LoadMap = append(d.LoadMap, LoadMapEntry{int32(e[0]), e[1], e[2]})
+		}
+	}
+	return d
+}
+
+// SetImageDef replaces the IMAGE_DEF of the image img with a new one that
+// contains the items of d. It modifies img and returns the updated image.
+//
+// The first block of the loop, that must be in the first 4 KiB of img, can't
+// grow so SetImageDef marks the IMAGE_DEF items in the existing blocks as
+// ignored and appends a new block to the end of img. The block appended by
+// the previous call of SetImageDef or Sign is removed. The new block becomes
+// the last one in the loop.
+func SetImageDef(img []byte, d *ImageDef) ([]byte, error) {
+	offs, blocks, err := Loop(img)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(blocks) - 1; n > 0 && blocks[n].Find(ItemImageType) != nil &&
+		erased(img[offs[n]+blocks[n].Size():]) {
+		img = img[:offs[n]]
+		offs, blocks = offs[:n], blocks[:n]
+	}
+	ignoreImageDefs(img, offs, blocks)
+	img, off := linkEnd(img, offs, blocks)
+	nb := &Block{Items: d.Items(), Link: int32(offs[0] - off)}
+	return append(img, nb.Bytes()...), nil
+}
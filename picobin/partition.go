@@ -0,0 +1,199 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package picobin
+
+import "errors"
+
+const (
+	SectorSize    = 4096 // partitions are defined in units of Flash sectors
+	MaxPartitions = 16
+)
+
+var ErrBadPartition = errors.New("picobin: bad partition")
+
+// Perm represents the access permissions to a partition. The same bits are
+// used in both words that describe the partition.
+type Perm uint32
+
+const (
+	PermSR  Perm = 1 << 26 // Secure read
+	PermSW  Perm = 1 << 27 // Secure write
+	PermNSR Perm = 1 << 28 // Non-secure read
+	PermNSW Perm = 1 << 29 // Non-secure write
+	PermBLR Perm = 1 << 30 // boot loader (NSBOOT) read
+	PermBLW Perm = 1 << 31 // boot loader (NSBOOT) write
+
+	PermAll Perm = 0x3f << 26
+)
+
+// PartFlags represents the partition flags.
+type PartFlags uint32
+
+const (
+	partHasID        PartFlags = 1 << 0
+	partLinkType     PartFlags = 3 << 1
+	partLinkValue    PartFlags = 15 << 3
+	partExtraFamilys PartFlags = 3 << 7
+	partHasName      PartFlags = 1 << 12
+
+	NotBootableArm   PartFlags = 1 << 9
+	NotBootableRISCV PartFlags = 1 << 10
+	OwnerAffinity    PartFlags = 1 << 11 // UF2 download to the A/B pair of the owner
+	NoReboot         PartFlags = 1 << 13 // don't reboot after UF2 download
+
+	// The UF2 families accepted by the partition
+	AcceptsRP2040   PartFlags = 1 << 14
+	AcceptsAbsolute PartFlags = 1 << 15
+	AcceptsData     PartFlags = 1 << 16
+	AcceptsArmS     PartFlags = 1 << 17
+	AcceptsRISCV    PartFlags = 1 << 18
+	AcceptsArmNS    PartFlags = 1 << 19
+)
+
+// LinkA returns the flags that make the partition the B partition of the A/B
+// pair with the partition a.
+func LinkA(a int) PartFlags {
+	return 1<<1 | PartFlags(a)<<3&partLinkValue
+}
+
+// LinkOwner returns the flags that make the partition owned by the partition
+// owner.
+func LinkOwner(owner int) PartFlags {
+	return 2<<1 | PartFlags(owner)<<3&partLinkValue
+}
+
+// Partition describes a partition.
+type Partition struct {
+	First, Last   int // first and last sector of the partition
+	Perm          Perm
+	Flags         PartFlags
+	ID            uint64 // saved in table if not zero
+	Name          string // saved in table if not empty
+	ExtraFamilies []uint32
+}
+
+// PartitionTable describes the PARTITION_TABLE item.
+type PartitionTable struct {
+	Singleton bool // the partition table is the only one (no A/B tables)
+
+	// Permissions and accepted families for the space outside partitions.
+	Perm  Perm
+	Flags PartFlags
+
+	Partitions []Partition
+}
+
+// Item returns the PARTITION_TABLE item described by pt.
+func (pt *PartitionTable) Item() (Item, error) {
+	if len(pt.Partitions) > MaxPartitions {
+		return nil, ErrBadPartition
+	}
+	it := Item{0, uint32(pt.Perm&PermAll) | uint32(pt.Flags&^0x1fff)}
+	for _, p := range pt.Partitions {
+		if p.First < 0 || p.Last < p.First || p.Last >= 1<<13 ||
+			len(p.ExtraFamilies) > 3 || len(p.Name) > 127 {
+			return nil, ErrBadPartition
+		}
+		perm := uint32(p.Perm & PermAll)
+		flags := p.Flags &^ (partHasID | partExtraFamilys | partHasName)
+		if p.ID != 0 {
+			flags |= partHasID
+		}
+		if p.Name != "" {
+			flags |= partHasName
+		}
+		flags |= PartFlags(len(p.ExtraFamilies)) << 7
+		it = append(it, perm|uint32(p.First)|uint32(p.Last)<<13, perm|uint32(flags))
+		if p.ID != 0 {
+			it = append(it, uint32(p.ID), uint32(p.ID>>32))
+		}
+		it = append(it, p.ExtraFamilies...)
+		if p.Name != "" {
+			b := append([]byte{byte(len(p.Name))}, p.Name...)
+			for len(b)&3 != 0 {
+				b = append(b, 0)
+			}
+			for i := 0; i < len(b); i += 4 {
+				it = append(it, uint32(b[i])|uint32(b[i+1])<<8|uint32(b[i+2])<<16|uint32(b[i+3])<<24)
+			}
+		}
+	}
+	it[0] = header(ItemPartitionTable, len(it)) | uint32(len(pt.Partitions))<<24
+	if pt.Singleton {
+		it[0] |= 1 << 31
+	}
+	return it, nil
+}
+
+// ParsePartitionTable decodes the PARTITION_TABLE item of b. It returns nil if
+// b doesn't contain a valid PARTITION_TABLE item.
+func ParsePartitionTable(b *Block) *PartitionTable {
+	it := b.Find(ItemPartitionTable)
+	if len(it) < 2 {
+		return nil
+	}
+	pt := &PartitionTable{
+		Singleton: it[0]>>31 != 0,
+		Perm:      Perm(it[1]) & PermAll,
+		Flags:     PartFlags(it[1]) &^ PartFlags(PermAll),
+	}
+	n := int(it[0] >> 24 & 0x1f)
+	w := it[2:]
+	for i := 0; i < n; i++ {
+		if len(w) < 2 {
+			return nil
+		}
+		p := Partition{
+			First: int(w[0] & 0x1fff),
+			Last:  int(w[0] >> 13 & 0x1fff),
+			Perm:  Perm(w[0]) & PermAll,
+			Flags: PartFlags(w[1]) &^ PartFlags(PermAll),
+		}
+		w = w[2:]
+		if p.Flags&partHasID != 0 {
+			if len(w) < 2 {
+				return nil
+			}
+			p.ID = uint64(w[0]) | uint64(w[1])<<32
+			w = w[2:]
+		}
+		if k := int(p.Flags & partExtraFamilys >> 7); k != 0 {
+			if len(w) < k {
+				return nil
+			}
+			p.ExtraFamilies = append([]uint32(nil), w[:k]...)
+			w = w[k:]
+		}
+		if p.Flags&partHasName != 0 {
+			if len(w) < 1 {
+				return nil
+			}
+			k := int(w[0] & 0x7f)
+			nw := (k + 4) / 4
+			if len(w) < nw {
+				return nil
+			}
+			name := make([]byte, 0, nw*4)
+			for _, v := range w[:nw] {
+				name = append(name, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+			}
+			p.Name = string(name[1 : k+1])
+			w = w[nw:]
+		}
+		p.Flags &^= partHasID | partExtraFamilys | partHasName
+		pt.Partitions = append(pt.Partitions, p)
+	}
+	return pt
+}
+
+// Offset returns the Flash offset of the partition in bytes.
+func (p *Partition) Offset() int {
+	return p.First * SectorSize
+}
+
+// Size returns the size of the partition in bytes.
+func (p *Partition) Size() int {
+	return (p.Last - p.First + 1) * SectorSize
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package secp256k1
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+)
+
+var ErrNoKey = errors.New("secp256k1: no secp256k1 key found")
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// SEC 1, section C.4
+type ecPrivateKey struct {
+	Version    int
+	PrivateKey []byte
+	Curve      asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey  asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// RFC 5208
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// RFC 5280
+type publicKeyInfo struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+func checkAlgo(algo *pkix.AlgorithmIdentifier) bool {
+	var curve asn1.ObjectIdentifier
+	if !algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return false
+	}
+	_, err := asn1.Unmarshal(algo.Parameters.FullBytes, &curve)
+	return err == nil && curve.Equal(oidSecp256k1)
+}
+
+func parseSEC1(der []byte, curveKnown bool) (*PrivateKey, error) {
+	var k ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &k); err != nil {
+		return nil, err
+	}
+	if !curveKnown && !k.Curve.Equal(oidSecp256k1) {
+		return nil, ErrNoKey
+	}
+	return NewPrivateKey(k.PrivateKey)
+}
+
+// ParsePrivateKey returns the first secp256k1 private key found in the PEM
+// encoded data. Both the "EC PRIVATE KEY" (SEC 1, as generated by the
+// `openssl ecparam -genkey -name secp256k1` command) and the "PRIVATE KEY"
+// (PKCS #8) blocks are supported.
+func ParsePrivateKey(data []byte) (*PrivateKey, error) {
+	for {
+		var b *pem.Block
+		if b, data = pem.Decode(data); b == nil {
+			return nil, ErrNoKey
+		}
+		switch b.Type {
+		case "EC PRIVATE KEY":
+			return parseSEC1(b.Bytes, false)
+		case "PRIVATE KEY":
+			var k pkcs8
+			if _, err := asn1.Unmarshal(b.Bytes, &k); err != nil {
+				return nil, err
+			}
+			if checkAlgo(&k.Algo) {
+				return parseSEC1(k.PrivateKey, true)
+			}
+		}
+	}
+}
+
+// ParsePublicKey returns the first secp256k1 public key found in the PEM
+// encoded data. The "PUBLIC KEY" blocks (as generated by the `openssl ec
+// -pubout` command) and the private key blocks (see ParsePrivateKey) are
+// supported.
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	for {
+		var b *pem.Block
+		if b, data = pem.Decode(data); b == nil {
+			return nil, ErrNoKey
+		}
+		switch b.Type {
+		case "PUBLIC KEY":
+			var k publicKeyInfo
+			if _, err := asn1.Unmarshal(b.Bytes, &k); err != nil {
+				return nil, err
+			}
+			if !checkAlgo(&k.Algo) {
+				continue
+			}
+			// Only the uncompressed form is supported.
+			q := k.PublicKey.RightAlign()
+			if len(q) != 65 || q[0] != 4 {
+				return nil, ErrBadKey
+			}
+			return NewPublicKey((*[64]byte)(q[1:]))
+		case "EC PRIVATE KEY", "PRIVATE KEY":
+			priv, err := ParsePrivateKey(pem.EncodeToMemory(b))
+			if err == ErrNoKey {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return &priv.PublicKey, nil
+		}
+	}
+}
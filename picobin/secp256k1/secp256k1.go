@@ -0,0 +1,218 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package secp256k1 implements the ECDSA signatures over the secp256k1 curve
+// in the form used by the RP2350 boot ROM: the 64-byte public key that
+// consists of the big-endian X and Y coordinates and the 64-byte signature
+// that consists of the big-endian R and S values.
+//
+// The implementation uses math/big and isn't constant time. It's intended for
+// the host tools that sign the firmware images, not for the untrusted
+// environments.
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrBadKey  = errors.New("secp256k1: bad key")
+	ErrBadHash = errors.New("secp256k1: bad hash length")
+)
+
+var (
+	p, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	n, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	gx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	gy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+	halfN = new(big.Int).Rsh(n, 1)
+	seven = big.NewInt(7)
+)
+
+// point is a curve point in affine coordinates. The nil X represents the
+// point at infinity.
+type point struct {
+	x, y *big.Int
+}
+
+func mod(a *big.Int) *big.Int {
+	return a.Mod(a, p)
+}
+
+func add(a, b point) point {
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+	var l *big.Int
+	if a.x.Cmp(b.x) == 0 {
+		if a.y.Cmp(b.y) != 0 || a.y.Sign() == 0 {
+			return point{}
+		}
+		// l = 3x²/2y
+		l = new(big.Int).Mul(a.x, a.x)
+		l.Mul(l, big.NewInt(3))
+		d := new(big.Int).Lsh(a.y, 1)
+		l.Mul(l, d.ModInverse(d, p))
+	} else {
+		// l = (by-ay)/(bx-ax)
+		l = new(big.Int).Sub(b.y, a.y)
+		d := mod(new(big.Int).Sub(b.x, a.x))
+		l.Mul(l, d.ModInverse(d, p))
+	}
+	mod(l)
+	x := new(big.Int).Mul(l, l)
+	x = mod(x.Sub(x.Sub(x, a.x), b.x))
+	y := new(big.Int).Sub(a.x, x)
+	y = mod(y.Sub(y.Mul(y, l), a.y))
+	return point{x, y}
+}
+
+func mul(a point, k *big.Int) point {
+	var r point
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = add(r, r)
+		if k.Bit(i) != 0 {
+			r = add(r, a)
+		}
+	}
+	return r
+}
+
+func onCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(p) >= 0 || y.Sign() < 0 || y.Cmp(p) >= 0 {
+		return false
+	}
+	l := mod(new(big.Int).Mul(y, y))
+	r := new(big.Int).Mul(x, x)
+	r = mod(r.Add(r.Mul(r, x), seven))
+	return l.Cmp(r) == 0
+}
+
+// PublicKey represents a secp256k1 public key.
+type PublicKey struct {
+	X, Y *big.Int
+}
+
+// NewPublicKey decodes the public key from its 64-byte form.
+func NewPublicKey(b *[64]byte) (*PublicKey, error) {
+	x := new(big.Int).SetBytes(b[:32])
+	y := new(big.Int).SetBytes(b[32:])
+	if !onCurve(x, y) {
+		return nil, ErrBadKey
+	}
+	return &PublicKey{x, y}, nil
+}
+
+// Bytes returns the 64-byte form of the public key.
+func (pub *PublicKey) Bytes() (b [64]byte) {
+	pub.X.FillBytes(b[:32])
+	pub.Y.FillBytes(b[32:])
+	return
+}
+
+// Verify reports whether sig is a valid signature of hash.
+func (pub *PublicKey) Verify(hash []byte, sig *[64]byte) bool {
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	z := hashInt(hash)
+	w := new(big.Int).ModInverse(s, n)
+	u1 := z.Mul(z, w)
+	u2 := w.Mul(r, w)
+	q := add(mul(point{gx, gy}, u1.Mod(u1, n)), mul(point{pub.X, pub.Y}, u2.Mod(u2, n)))
+	if q.x == nil {
+		return false
+	}
+	return q.x.Mod(q.x, n).Cmp(r) == 0
+}
+
+// PrivateKey represents a secp256k1 private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+// NewPrivateKey returns the private key with the 32-byte big-endian scalar d.
+func NewPrivateKey(d []byte) (*PrivateKey, error) {
+	k := new(big.Int).SetBytes(d)
+	if len(d) > 32 || k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, ErrBadKey
+	}
+	q := mul(point{gx, gy}, k)
+	return &PrivateKey{PublicKey{q.x, q.y}, k}, nil
+}
+
+// hashInt converts the 32-byte hash to the integer (bits2int in RFC 6979).
+func hashInt(hash []byte) *big.Int {
+	if len(hash) > 32 {
+		hash = hash[:32]
+	}
+	return new(big.Int).SetBytes(hash)
+}
+
+// Sign signs the 32-byte hash using the deterministic nonce generation
+// described in RFC 6979 so the same image and key always give the same
+// signature. The S value is normalized to the lower half of the group order
+// (S <= N/2, the form produced by libsecp256k1). The boot ROM uses the plain
+// ECDSA verification that accepts both S and N-S (picotool, that uses mbed TLS,
+// doesn't normalize S) so this only makes the signature canonical.
+func (priv *PrivateKey) Sign(hash []byte) (sig [64]byte, err error) {
+	if len(hash) != 32 {
+		return sig, ErrBadHash
+	}
+	z := hashInt(hash)
+	var x, h1 [32]byte
+	priv.D.FillBytes(x[:])
+	new(big.Int).Mod(z, n).FillBytes(h1[:])
+
+	// RFC 6979 section 3.2
+	v := make([]byte, 32)
+	k := make([]byte, 32)
+	for i := range v {
+		v[i] = 1
+	}
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	k = mac(k, v, []byte{0}, x[:], h1[:])
+	v = mac(k, v)
+	k = mac(k, v, []byte{1}, x[:], h1[:])
+	v = mac(k, v)
+	for {
+		v = mac(k, v)
+		kn := new(big.Int).SetBytes(v)
+		if kn.Sign() > 0 && kn.Cmp(n) < 0 {
+			q := mul(point{gx, gy}, kn)
+			r := q.x.Mod(q.x, n)
+			if r.Sign() != 0 {
+				s := new(big.Int).Mul(r, priv.D)
+				s.Add(s, z)
+				s.Mul(s, kn.ModInverse(kn, n))
+				s.Mod(s, n)
+				if s.Cmp(halfN) > 0 {
+					s.Sub(n, s)
+				}
+				if s.Sign() != 0 {
+					r.FillBytes(sig[:32])
+					s.FillBytes(sig[32:])
+					return sig, nil
+				}
+			}
+		}
+		k = mac(k, v, []byte{0})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package secp256k1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestPublicKey(t *testing.T) {
+	keys := []struct{ d, x, y string }{
+		{
+			"01",
+			"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		}, {
+			"02",
+			"c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5",
+			"1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+		}, {
+			"03",
+			"f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			"388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+		}, {
+			// N-1, -G
+			"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+			"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			"b7c52588d95c3b9aa25b0403f1eef75702e84bb7597aabe663b82f6f04ef2777",
+		},
+	}
+	for _, k := range keys {
+		priv, err := NewPrivateKey(unhex(t, k.d))
+		if err != nil {
+			t.Fatalf("%s: %v", k.d, err)
+		}
+		b := priv.Bytes()
+		if x := hex.EncodeToString(b[:32]); x != k.x {
+			t.Errorf("%s: X: got %s, want %s", k.d, x, k.x)
+		}
+		if y := hex.EncodeToString(b[32:]); y != k.y {
+			t.Errorf("%s: Y: got %s, want %s", k.d, y, k.y)
+		}
+		pub, err := NewPublicKey(&b)
+		if err != nil {
+			t.Errorf("%s: NewPublicKey: %v", k.d, err)
+		} else if pub.X.Cmp(priv.X) != 0 || pub.Y.Cmp(priv.Y) != 0 {
+			t.Errorf("%s: NewPublicKey: got %x, %x", k.d, pub.X, pub.Y)
+		}
+	}
+}
+
+func TestBadKey(t *testing.T) {
+	for _, d := range []string{
+		"00",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", // N
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"010000000000000000000000000000000000000000000000000000000000000000",
+	} {
+		if _, err := NewPrivateKey(unhex(t, d)); err != ErrBadKey {
+			t.Errorf("NewPrivateKey(%s): got %v, want %v", d, err, ErrBadKey)
+		}
+	}
+	var b [64]byte
+	g := point{gx, gy}
+	g.x.FillBytes(b[:32])
+	g.y.FillBytes(b[32:])
+	b[63] ^= 1
+	if _, err := NewPublicKey(&b); err != ErrBadKey {
+		t.Errorf("NewPublicKey(G+1): got %v, want %v", err, ErrBadKey)
+	}
+}
+
+// RFC 6979 signatures with the low S, as produced by libsecp256k1.
+var signTests = []struct {
+	d, msg, r, s string
+}{
+	{
+		"01",
+		"Satoshi Nakamoto",
+		"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+	}, {
+		"01",
+		"All those moments will be lost in time, like tears in rain. Time to die...",
+		"8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+		"547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+	}, {
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		"Satoshi Nakamoto",
+		"fd567d121db66e382991534ada77a6bd3106f0a1098c231e47993447cd6af2d0",
+		"6b39cd0eb1bc8603e159ef5c20a5c8ad685a45b06ce9bebed3f153d10d93bed5",
+	}, {
+		"f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181",
+		"Alan Turing",
+		"7063ae83e7f62bbb171798131b4a0564b956930092b33b07b395615d9ec7e15c",
+		"58dfcc1e00a35e1572f366ffe34ba0fc47db1e7189759b9fb233c5b05ab388ea",
+	},
+}
+
+func TestSign(t *testing.T) {
+	for _, st := range signTests {
+		priv, err := NewPrivateKey(unhex(t, st.d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256([]byte(st.msg))
+		sig, err := priv.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := hex.EncodeToString(sig[:32]); r != st.r {
+			t.Errorf("%s, %q: R: got %s, want %s", st.d, st.msg, r, st.r)
+		}
+		if s := hex.EncodeToString(sig[32:]); s != st.s {
+			t.Errorf("%s, %q: S: got %s, want %s", st.d, st.msg, s, st.s)
+		}
+		if !priv.Verify(hash[:], &sig) {
+			t.Errorf("%s, %q: Verify failed", st.d, st.msg)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for i := 1; i <= 20; i++ {
+		d := sha256.Sum256([]byte{byte(i)})
+		priv, err := NewPrivateKey(d[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash := sha256.Sum256([]byte{byte(i), 'm', 's', 'g'})
+		sig, err := priv.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := new(big.Int).SetBytes(sig[32:]); s.Cmp(halfN) > 0 {
+			t.Errorf("%d: high S: %x", i, s)
+		}
+		sig2, _ := priv.Sign(hash[:])
+		if sig2 != sig {
+			t.Errorf("%d: Sign isn't deterministic", i)
+		}
+		b := priv.Bytes()
+		pub, err := NewPublicKey(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pub.Verify(hash[:], &sig) {
+			t.Errorf("%d: Verify failed", i)
+		}
+
+		// The high S form is valid too.
+		hs := sig
+		s := new(big.Int).SetBytes(sig[32:])
+		s.Sub(n, s).FillBytes(hs[32:])
+		if !pub.Verify(hash[:], &hs) {
+			t.Errorf("%d: Verify failed for N-S", i)
+		}
+
+		badHash := hash
+		badHash[i] ^= 0x10
+		if pub.Verify(badHash[:], &sig) {
+			t.Errorf("%d: Verify succeeded for modified hash", i)
+		}
+		bad := sig
+		bad[i] ^= 0x10
+		if pub.Verify(hash[:], &bad) {
+			t.Errorf("%d: Verify succeeded for modified R", i)
+		}
+		bad = sig
+		bad[32+i] ^= 0x10
+		if pub.Verify(hash[:], &bad) {
+			t.Errorf("%d: Verify succeeded for modified S", i)
+		}
+		var zero [64]byte
+		if pub.Verify(hash[:], &zero) {
+			t.Errorf("%d: Verify succeeded for zero signature", i)
+		}
+	}
+	priv, _ := NewPrivateKey([]byte{1})
+	if _, err := priv.Sign(make([]byte, 31)); err != ErrBadHash {
+		t.Errorf("Sign(31 bytes): got %v, want %v", err, ErrBadHash)
+	}
+}
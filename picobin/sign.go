@@ -0,0 +1,134 @@
+// Copyright 2025 The Embedded Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package picobin
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	HashSHA256   = 1 // HASH_DEF hash type
+	SigSECP256K1 = 1 // SIGNATURE type
+)
+
+var ErrNoImageDef = errors.New("picobin: no IMAGE_DEF block")
+
+// KeyHash returns the boot key fingerprint: the SHA-256 hash of the public key
+// pub, as stored in the SIGNATURE item (X and Y coordinates, big-endian).
+func KeyHash(pub *[64]byte) [32]byte {
+	return sha256.Sum256(pub[:])
+}
+
+// BootKeyRows returns the content of the 16 OTP rows of the BOOTKEYn slot
+// (ECC protected) that correspond to the public key pub.
+func BootKeyRows(pub *[64]byte) (rows [16]uint16) {
+	h := KeyHash(pub)
+	for i := range rows {
+		rows[i] = binary.LittleEndian.Uint16(h[2*i:])
+	}
+	return
+}
+
+// LoadMapEntry describes a range of the image data.
+type LoadMapEntry struct {
+	Storage int32  // storage address relative to the block start
+	Runtime uint32 // runtime address
+	Size    uint32 // size in bytes
+}
+
+// LoadMapItem returns the LOAD_MAP item with the relative storage addresses.
+func LoadMapItem(entries ...LoadMapEntry) Item {
+	it := Item{header(ItemLoadMap, 1+3*len(entries)) | uint32(len(entries))<<24}
+	for _, e := range entries {
+		it = append(it, uint32(e.Storage), e.Runtime, e.Size)
+	}
+	return it
+}
+
+// HashDefItem returns the HASH_DEF item that defines the SHA-256 hash that
+// covers the image data (see LOAD_MAP) and the first words of the block.
+func HashDefItem(words int) Item {
+	return Item{header(ItemHashDef, 2) | HashSHA256<<24, uint32(words)}
+}
+
+// SignatureItem returns the SIGNATURE item that contains the secp256k1 public
+// key pub and the signature sig (R and S, big-endian).
+func SignatureItem(pub, sig *[64]byte) Item {
+	it := Item{header(ItemSignature, 33) | SigSECP256K1<<24}
+	for i := 0; i < 64; i += 4 {
+		it = append(it, binary.LittleEndian.Uint32(pub[i:]))
+	}
+	for i := 0; i < 64; i += 4 {
+		it = append(it, binary.LittleEndian.Uint32(sig[i:]))
+	}
+	return it
+}
+
+// Sign signs the image img that is loaded at the address base. It modifies img
+// and returns the signed image. Sign removes the previous signature block, if
+// any, and appends a new block to the end of img (after padding it to the word
+// boundary) that contains the copy of the last IMAGE_DEF found in the block
+// loop, the LOAD_MAP that covers the whole image data, HASH_DEF and SIGNATURE
+// items. As the boot ROM uses the last IMAGE_DEF in the loop the appended
+// block supersedes the original one. The IMAGE_DEF items in the other blocks
+// are additionally marked as ignored so the image contains only one valid
+// IMAGE_DEF (the signed one). The image signed this way can be signed again:
+// the IMAGE_DEF is then taken from the previous signature block.
+//
+// The sign function is called to sign the SHA-256 hash of the image with the
+// private key that corresponds to pub.
+func Sign(img []byte, base uint32, pub *[64]byte, sign func(hash []byte) ([64]byte, error)) ([]byte, error) {
+	offs, blocks, err := Loop(img)
+	if err != nil {
+		return nil, err
+	}
+	var def *Block
+	for _, b := range blocks {
+		if b.Find(ItemImageType) != nil {
+			def = b
+		}
+	}
+	if def == nil {
+		return nil, ErrNoImageDef
+	}
+	// Drop the signature block appended by the previous Sign call (it may be
+	// followed by the erased Flash content, eg. if img was read from UF2).
+	if n := len(blocks) - 1; n > 0 && blocks[n].Find(ItemSignature) != nil &&
+		erased(img[offs[n]+blocks[n].Size():]) {
+		img = img[:offs[n]]
+		offs, blocks = offs[:n], blocks[:n]
+	}
+	ignoreImageDefs(img, offs, blocks)
+	img, off := linkEnd(img, offs, blocks)
+	nb := &Block{Link: int32(offs[0] - off)}
+	for _, it := range def.Items {
+		switch it.Type() {
+		case ItemLoadMap, ItemHashDef, ItemHashValue, ItemSignature, ItemPartitionTable, ItemIgnored:
+			continue
+		}
+		nb.Items = append(nb.Items, it)
+	}
+	nb.Items = append(nb.Items, LoadMapItem(LoadMapEntry{
+		Storage: int32(-off), Runtime: base, Size: uint32(off),
+	}))
+	words := 1 // block start marker
+	for _, it := range nb.Items {
+		words += len(it)
+	}
+	words += 2 // HASH_DEF
+	nb.Items = append(nb.Items, HashDefItem(words))
+
+	h := sha256.New()
+	h.Write(img)
+	h.Write(nb.Bytes()[:words*4])
+	sig, err := sign(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	nb.Items = append(nb.Items, SignatureItem(pub, &sig))
+	return append(img, nb.Bytes()...), nil
+}